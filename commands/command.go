@@ -16,19 +16,25 @@ import (
 
 type (
 	Command struct {
+		// Config is the config which the environment variables are expanded, except mysqlConfig expanded on connecting
 		Config dependency.Config
-		ReadAt time.Time
+		// RawConfig is the config as written in the file, which is written back instead of Config
+		RawConfig dependency.Config
+		ReadAt    time.Time
 	}
 )
 
-// NewCommandFromJSON new command from json file
+// NewCommandFromJSON new command from config file, which is json, yaml or toml by the extension
 func NewCommandFromJSON(configPath, dbName string) (*Command, error) {
 	b, err := helper.ReadFile(configPath)
 	if err != nil {
 		return nil, err
 	}
 	com := Command{}
-	if err := com.Config.ParseJSON(b); err != nil {
+	if err := com.RawConfig.Parse(b, dependency.GetConfigFormat(configPath)); err != nil {
+		return nil, err
+	}
+	if com.Config, err = com.RawConfig.ExpandEnv(); err != nil {
 		return nil, err
 	}
 	if dbName != "" {
@@ -40,8 +46,7 @@ func NewCommandFromJSON(configPath, dbName string) (*Command, error) {
 
 // GenerateJSON generate json file
 func (cmd Command) GenerateJSON() error {
-	dbconf := cmd.Config.MysqlConfig
-	con, err := mysql.NewConnection(dbconf.Host, dbconf.Port, dbconf.User, dbconf.Password, dbconf.DbName, false)
+	con, err := cmd.connect()
 	if err != nil {
 		return err
 	}
@@ -50,6 +55,15 @@ func (cmd Command) GenerateJSON() error {
 	return writeTablesJSON(con, outputPath)
 }
 
+// connect connects to the database with the connection options and the credentials of the config
+func (cmd Command) connect() (*mysql.Connection, error) {
+	opts, err := cmd.Config.MysqlConfig.DSNOptions()
+	if err != nil {
+		return nil, err
+	}
+	return mysql.NewConnection(opts, false)
+}
+
 func (cmd Command) GenerateSourceFromJSON(table string) error {
 
 	config := cmd.Config
@@ -59,18 +73,10 @@ func (cmd Command) GenerateSourceFromJSON(table string) error {
 	}
 
 	// check json path
-	dbname := config.MysqlConfig.DbName
-	if dbname == "" {
-		return errors.New("No database name selected in config")
-	}
-	path := strings.Replace(config.OutputJSONPath, "{dbname}", dbname, -1)
-	info, err := os.Lstat(path)
+	path, err := cmd.tablesJSONPath()
 	if err != nil {
 		return err
 	}
-	if !info.IsDir() {
-		return fmt.Errorf("json path must be directory")
-	}
 
 	// check and craete output path
 	if err := helper.CreateDirIfNotExist(config.OutputSourcePath); err != nil {
@@ -84,12 +90,11 @@ func (cmd Command) GenerateSourceFromJSON(table string) error {
 
 	var pTables []scaffold.TemplateDataTable
 	var outputSource = func(path string) error {
-		var table mysql.Table
-		if err := helper.ReadFileJSON(path, &table); err != nil {
+		pTable, err := cmd.readTemplateDataTable(path)
+		if err != nil {
 			return err
 		}
 		fmt.Println("file:", path)
-		pTable := scaffold.NewTamplateParamTable(cmd.Config.PackageRoot, table, config.CommonColumns, config.CustomColumnType)
 		data := scaffold.TemplateData{
 			Config: cmd.Config,
 			Table:  pTable,
@@ -111,18 +116,7 @@ func (cmd Command) GenerateSourceFromJSON(table string) error {
 		}
 	} else {
 		// Target all files on the specified path
-		if err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
-			if info == nil || info.IsDir() || err != nil {
-				return err
-			}
-			name := info.Name()
-			tableName := strings.TrimSuffix(name, ".json")
-			if len(targetTables) == 0 && helper.StringsContains(cmd.Config.IgnoreTableNames, tableName) {
-				fmt.Println("file:", name, "[ignore]")
-				return nil
-			}
-			return outputSource(path)
-		}); err != nil {
+		if err := cmd.walkTableJSON(path, outputSource); err != nil {
 			return err
 		}
 	}
@@ -140,8 +134,29 @@ func (cmd Command) GenerateSourceFromJSON(table string) error {
 		return err
 	}
 
+	// the templates by once always refer to all tables
+	if len(targetTables) > 0 {
+		pTables = nil
+		if err := cmd.walkTableJSON(path, func(path string) error {
+			pTable, err := cmd.readTemplateDataTable(path)
+			if err != nil {
+				return err
+			}
+			pTables = append(pTables, pTable)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return myTemplate.OutputSourceFileTable(cmd.newTemplateDataByOnce(pTables))
+}
+
+// newTemplateDataByOnce returns the data of the templates by once, which refers to all tables
+func (cmd Command) newTemplateDataByOnce(pTables []scaffold.TemplateDataTable) scaffold.TemplateData {
 	data := scaffold.TemplateData{
 		Config: cmd.Config,
+		Tables: pTables,
 	}
 
 	// set common column
@@ -154,5 +169,46 @@ func (cmd Command) GenerateSourceFromJSON(table string) error {
 			}
 		}
 	}
-	return myTemplate.OutputSourceFileTable(data)
+	return data
+}
+
+// tablesJSONPath returns the directory of the tables json
+func (cmd Command) tablesJSONPath() (string, error) {
+	dbname := cmd.Config.MysqlConfig.DbName
+	if dbname == "" {
+		return "", errors.New("No database name selected in config")
+	}
+	path := strings.Replace(cmd.Config.OutputJSONPath, "{dbname}", dbname, -1)
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("json path must be directory")
+	}
+	return path, nil
+}
+
+func (cmd Command) readTemplateDataTable(path string) (scaffold.TemplateDataTable, error) {
+	table, err := mysql.ReadTableJSON(path)
+	if err != nil {
+		return scaffold.TemplateDataTable{}, err
+	}
+	return scaffold.NewTamplateParamTable(cmd.Config, table), nil
+}
+
+// walkTableJSON calls fn for each table json on the path except the ignored tables
+func (cmd Command) walkTableJSON(path string, fn func(path string) error) error {
+	return filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+		if info == nil || info.IsDir() || err != nil {
+			return err
+		}
+		name := info.Name()
+		tableName := strings.TrimSuffix(name, ".json")
+		if helper.StringsContains(cmd.Config.IgnoreTableNames, tableName) {
+			fmt.Println("file:", name, "[ignore]")
+			return nil
+		}
+		return fn(path)
+	})
 }
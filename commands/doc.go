@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"github.com/suzujun/gendao/dependency"
+	"github.com/suzujun/gendao/scaffold"
+)
+
+// the templates of the documents in the input template path, part_method_name.tpl is used for the methods
+var (
+	docTemplateByOnce      = []dependency.TemplateFile{{Name: "doc.tpl", ExportName: "README.md", Overwrite: true}}
+	docTemplateToTableLoop = []dependency.TemplateFile{
+		{Name: "doc_xxx.tpl", ExportName: "{name}.md", Overwrite: true},
+		{Name: "part_method_name.tpl"},
+	}
+)
+
+// GenerateDoc generates the Markdown documents of the tables from the json into outputPath,
+// which are a page for each table and an index page with ER diagram
+func (cmd Command) GenerateDoc(outputPath string) error {
+	path, err := cmd.tablesJSONPath()
+	if err != nil {
+		return err
+	}
+	var pTables []scaffold.TemplateDataTable
+	if err := cmd.walkTableJSON(path, func(path string) error {
+		pTable, err := cmd.readTemplateDataTable(path)
+		if err != nil {
+			return err
+		}
+		pTables = append(pTables, pTable)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	myTemplate, err := scaffold.NewTemplate(cmd.Config.InputTemplatePath, docTemplateToTableLoop, outputPath)
+	if err != nil {
+		return err
+	}
+	for _, pTable := range pTables {
+		data := scaffold.TemplateData{
+			Config: cmd.Config,
+			Table:  pTable,
+			Tables: pTables,
+		}
+		if err := myTemplate.OutputSourceFileTable(data); err != nil {
+			return err
+		}
+	}
+
+	myTemplate, err = scaffold.NewTemplate(cmd.Config.InputTemplatePath, docTemplateByOnce, outputPath)
+	if err != nil {
+		return err
+	}
+	return myTemplate.OutputSourceFileTable(scaffold.TemplateData{Config: cmd.Config, Tables: pTables})
+}
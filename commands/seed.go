@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/suzujun/gendao/helper/mysql"
+	"github.com/suzujun/gendao/seed"
+)
+
+// Seed inserts dummy rows into each table of the json, in the order of the foreign keys
+func (cmd Command) Seed(table string, rows int, randSeed int64) error {
+	path, err := cmd.tablesJSONPath()
+	if err != nil {
+		return err
+	}
+	var tables []mysql.Table
+	readTable := func(path string) error {
+		table, err := mysql.ReadTableJSON(path)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, table)
+		return nil
+	}
+	if table != "" {
+		// Only specified file
+		for _, name := range strings.Split(table, ",") {
+			if err := readTable(filepath.Join(path, fmt.Sprintf("%s.json", name))); err != nil {
+				return err
+			}
+		}
+	} else {
+		// Target all files on the specified path
+		if err := cmd.walkTableJSON(path, readTable); err != nil {
+			return err
+		}
+	}
+
+	con, err := cmd.connect()
+	if err != nil {
+		return err
+	}
+	defer con.Close()
+
+	fmt.Println("seed:", randSeed)
+	seeder := seed.NewSeeder(con.DB(), randSeed)
+	for _, table := range seed.SortTables(tables) {
+		n, err := seeder.SeedTable(table, rows)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("table: %s [%d rows]\n", table.Name, n)
+	}
+	return nil
+}
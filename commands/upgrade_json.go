@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/suzujun/gendao/helper"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+// UpgradeJSON migrates the table json of the older version to the current version
+func (cmd Command) UpgradeJSON() error {
+	path, err := cmd.tablesJSONPath()
+	if err != nil {
+		return err
+	}
+	return cmd.walkTableJSON(path, func(path string) error {
+		b, err := helper.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		res, version, err := mysql.UpgradeTableJSON(b)
+		if err != nil {
+			return fmt.Errorf("%s, [%s]", err, path)
+		}
+		if version == mysql.TableJSONVersion {
+			fmt.Println("upgrade:", path, "[skip latest]")
+			return nil
+		}
+		if _, err := helper.CreateFile(path, res); err != nil {
+			return err
+		}
+		fmt.Printf("upgrade: %s [v%d -> v%d]\n", path, version, mysql.TableJSONVersion)
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/suzujun/gendao/dependency"
+	"github.com/suzujun/gendao/helper"
+	"github.com/suzujun/gendao/helper/mysql"
+	"github.com/suzujun/gendao/scaffold"
+)
+
+// Validate returns every problem of the config, the templates and the tables json.
+// The templates are executed with the tables to find the errors of execution, but no file is written.
+func (cmd Command) Validate() []string {
+	var problems []string
+	path, err := cmd.tablesJSONPath()
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("outputJsonPath is invalid, %s", err))
+	}
+
+	// tables json
+	tables := map[string][]string{}
+	var pTables []scaffold.TemplateDataTable
+	if err == nil {
+		err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+			if info == nil || info.IsDir() || err != nil || !strings.HasSuffix(info.Name(), ".json") {
+				return err
+			}
+			b, err := helper.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if tableProblems := mysql.ValidateTableJSON(b); len(tableProblems) > 0 {
+				for _, p := range tableProblems {
+					problems = append(problems, fmt.Sprintf("%s: %s", path, p))
+				}
+				return nil
+			}
+			var table mysql.Table
+			if err := json.Unmarshal(b, &table); err != nil {
+				return err
+			}
+			for _, column := range table.Columns {
+				tables[table.Name] = append(tables[table.Name], column.ColumnName)
+			}
+			if !helper.StringsContains(cmd.Config.IgnoreTableNames, table.Name) {
+				pTables = append(pTables, scaffold.NewTamplateParamTable(cmd.Config, table))
+			}
+			return nil
+		})
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	// config
+	problems = append(problems, cmd.Config.Validate(tables)...)
+
+	// templates
+	problems = append(problems, cmd.validateTemplates("templateToTableLoop", cmd.Config.TemplateToTableLoop, pTables, true)...)
+	problems = append(problems, cmd.validateTemplates("templateByOnce", cmd.Config.TemplateByOnce, pTables, false)...)
+	return problems
+}
+
+// validateTemplates parses the templates and executes them with the tables, each template is executed with each table if loop
+func (cmd Command) validateTemplates(field string, tmplFiles []dependency.TemplateFile, pTables []scaffold.TemplateDataTable, loop bool) []string {
+	for _, tf := range tmplFiles {
+		if tf.Name == "" || !helper.IsFileExist(filepath.Join(cmd.Config.InputTemplatePath, tf.Name)) {
+			return nil // reported by the config
+		}
+	}
+	tmpl, err := scaffold.ParseTemplates(cmd.Config.InputTemplatePath, tmplFiles)
+	if err != nil {
+		return []string{fmt.Sprintf("%s failed to parse, %s", field, err)}
+	}
+	var problems []string
+	for i, tf := range tmplFiles {
+		if tf.ExportName == "" {
+			continue
+		}
+		datas := []scaffold.TemplateData{cmd.newTemplateDataByOnce(pTables)}
+		if loop {
+			datas = datas[:0]
+			for _, pTable := range pTables {
+				datas = append(datas, scaffold.TemplateData{Config: cmd.Config, Table: pTable})
+			}
+		}
+		for _, data := range datas {
+			if err := tmpl.ExecuteTemplate(ioutil.Discard, tf.Name, data); err != nil {
+				problems = append(problems, fmt.Sprintf("%s[%d] failed to execute, %s", field, i, err))
+				break // the same error for the other tables
+			}
+		}
+	}
+	return problems
+}
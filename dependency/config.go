@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/suzujun/gendao/helper"
@@ -11,37 +12,78 @@ import (
 
 type (
 	Config struct {
-		PackageRoot         string                       `json:"packageRoot"`
-		CommonColumns       []string                     `json:"commonColumns"`
-		MysqlConfig         MysqlConfig                  `json:"mysqlConfig"`
-		OutputJSONPath      string                       `json:"outputJsonPath"`
-		OutputSourcePath    string                       `json:"outputSourcePath"`
-		InputTemplatePath   string                       `json:"inputTemplatePath"`
-		TemplateByOnce      []TemplateFile               `json:"templateByOnce"`
-		TemplateToTableLoop []TemplateFile               `json:"templateToTableLoop"`
-		IgnoreTableNames    []string                     `json:"ignoreTableNames"`
-		CustomColumnType    map[string]*CustomColumnType `json:"customColumnTypes"`
+		PackageRoot         string                       `json:"packageRoot" yaml:"packageRoot" toml:"packageRoot"`
+		CommonColumns       []string                     `json:"commonColumns" yaml:"commonColumns" toml:"commonColumns"`
+		MysqlConfig         MysqlConfig                  `json:"mysqlConfig" yaml:"mysqlConfig" toml:"mysqlConfig"`
+		OutputJSONPath      string                       `json:"outputJsonPath" yaml:"outputJsonPath" toml:"outputJsonPath"`
+		OutputSourcePath    string                       `json:"outputSourcePath" yaml:"outputSourcePath" toml:"outputSourcePath"`
+		InputTemplatePath   string                       `json:"inputTemplatePath" yaml:"inputTemplatePath" toml:"inputTemplatePath"`
+		TemplateByOnce      []TemplateFile               `json:"templateByOnce" yaml:"templateByOnce" toml:"templateByOnce"`
+		TemplateToTableLoop []TemplateFile               `json:"templateToTableLoop" yaml:"templateToTableLoop" toml:"templateToTableLoop"`
+		IgnoreTableNames    []string                     `json:"ignoreTableNames" yaml:"ignoreTableNames" toml:"ignoreTableNames"`
+		UpsertIgnoreColumns []string                     `json:"upsertIgnoreColumns" yaml:"upsertIgnoreColumns" toml:"upsertIgnoreColumns"`
+		VersionColumn       string                       `json:"versionColumn" yaml:"versionColumn" toml:"versionColumn"`
+		VersionColumns      map[string]string            `json:"versionColumns" yaml:"versionColumns" toml:"versionColumns"`
+		SoftDeleteColumn    string                       `json:"softDeleteColumn" yaml:"softDeleteColumn" toml:"softDeleteColumn"`
+		ProtoPackage        string                       `json:"protoPackage" yaml:"protoPackage" toml:"protoPackage"`
+		ProtoGoPackage      string                       `json:"protoGoPackage" yaml:"protoGoPackage" toml:"protoGoPackage"`
+		JSONNaming          string                       `json:"jsonNaming" yaml:"jsonNaming" toml:"jsonNaming"`
+		CustomColumnType    map[string]*CustomColumnType `json:"customColumnTypes" yaml:"customColumnTypes" toml:"customColumnTypes"`
 	}
 	TemplateFile struct {
-		Name       string `json:"name"`
-		ExportName string `json:"exportName"`
-		Overwrite  bool   `json:"overwrite"`
+		Name       string `json:"name" yaml:"name" toml:"name"`
+		ExportName string `json:"exportName" yaml:"exportName" toml:"exportName"`
+		Overwrite  bool   `json:"overwrite" yaml:"overwrite" toml:"overwrite"`
 	}
 	MysqlConfig struct {
-		Host     string `json:"host"`
-		Port     string `json:"port"`
-		User     string `json:"user"`
-		Password string `json:"password"`
-		DbName   string `json:"dbName"`
+		Host     string `json:"host" yaml:"host" toml:"host"`
+		Port     string `json:"port" yaml:"port" toml:"port"`
+		User     string `json:"user" yaml:"user" toml:"user"`
+		Password string `json:"password" yaml:"password" toml:"password"`
+		DbName   string `json:"dbName" yaml:"dbName" toml:"dbName"`
+		// PasswordEnv and PasswordFile are the environment variable and the file of the password, see Credentials
+		PasswordEnv  string `json:"passwordEnv" yaml:"passwordEnv" toml:"passwordEnv"`
+		PasswordFile string `json:"passwordFile" yaml:"passwordFile" toml:"passwordFile"`
+		// OptionFile is the option file of MySQL, ~/.my.cnf by default
+		OptionFile string `json:"optionFile" yaml:"optionFile" toml:"optionFile"`
+		// LoginPath is the login path of .mylogin.cnf, client by default
+		LoginPath string `json:"loginPath" yaml:"loginPath" toml:"loginPath"`
+		// DSN is the data source name of the driver, e.g. user:password@tcp(localhost:3306)/dbname?charset=utf8mb4.
+		// The address and the credentials of DSN are prior to host, port, socket, user and password.
+		// The database of DSN is used if dbName is empty.
+		DSN string `json:"dsn" yaml:"dsn" toml:"dsn"`
+		// Socket is the path of the unix socket, which is used instead of host and port
+		Socket string `json:"socket" yaml:"socket" toml:"socket"`
+		// TLSMode is one of false, true, skip-verify and preferred, the certificate files are used with true or skip-verify
+		TLSMode string `json:"tlsMode" yaml:"tlsMode" toml:"tlsMode"`
+		TLSCA   string `json:"tlsCA" yaml:"tlsCA" toml:"tlsCA"`
+		TLSCert string `json:"tlsCert" yaml:"tlsCert" toml:"tlsCert"`
+		TLSKey  string `json:"tlsKey" yaml:"tlsKey" toml:"tlsKey"`
+		// ConnectTimeout and ReadTimeout are the durations, e.g. 10s
+		ConnectTimeout string `json:"connectTimeout" yaml:"connectTimeout" toml:"connectTimeout"`
+		ReadTimeout    string `json:"readTimeout" yaml:"readTimeout" toml:"readTimeout"`
+		// Params is the other parameters of the driver, e.g. charset
+		Params map[string]string `json:"params,omitempty" yaml:"params,omitempty" toml:"params,omitempty"`
 	}
 	CustomColumnType struct {
-		Type         string `json:"type"`
-		SampleValue  string `json:"sampleValue"`
-		Package      string `json:"package"`
-		PackageAlias string `json:"packageAlias"`
+		Type         string `json:"type" yaml:"type" toml:"type"`
+		SampleValue  string `json:"sampleValue" yaml:"sampleValue" toml:"sampleValue"`
+		Package      string `json:"package" yaml:"package" toml:"package"`
+		PackageAlias string `json:"packageAlias" yaml:"packageAlias" toml:"packageAlias"`
 	}
 )
 
+// the values of jsonNaming, the json tags of the models are not generated by default
+const (
+	JSONNamingSnake  = "snake"
+	JSONNamingCamel  = "camel"
+	JSONNamingPascal = "pascal"
+)
+
+var defaultUpsertIgnoreColumns = []string{
+	"created_at",
+}
+
 func NewConfig(host, port, user, password, database string) Config {
 	conf := newConfig()
 	if host != "" {
@@ -80,11 +122,13 @@ func newConfig() Config {
 			"created_at",
 			"updated_at",
 		},
-		OutputJSONPath:    "./out/{dbname}",
-		OutputSourcePath:  "./src",
-		InputTemplatePath: "./template",
-		TemplateByOnce:    []TemplateFile{
-		// {Name: "model.tpl", ExportName: "model/model.go"}, // dao/model.go
+		UpsertIgnoreColumns: defaultUpsertIgnoreColumns,
+		OutputJSONPath:      "./out/{dbname}",
+		OutputSourcePath:    "./src",
+		InputTemplatePath:   "./template",
+		TemplateByOnce: []TemplateFile{
+			{Name: "dao.tpl", ExportName: "dao/dao.go", Overwrite: true}, // dao/dao.go
+			// {Name: "model.tpl", ExportName: "model/model.go"}, // dao/model.go
 		},
 		TemplateToTableLoop: []TemplateFile{
 			{Name: "dao_xxx.tpl", ExportName: "dao/{name}.go", Overwrite: false},        // dao/channel.go
@@ -96,8 +140,18 @@ func newConfig() Config {
 	}
 }
 
+// Write writes the config in the format by the extension of path, the comments of the existing file are kept if possible
 func (c Config) Write(path string) error {
-	b, err := c.ExportJSON()
+	format := GetConfigFormat(path)
+	var original []byte
+	if format != ConfigFormatJSON && helper.IsFileExist(path) {
+		b, err := helper.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		original = b
+	}
+	b, err := c.Export(format, original)
 	if err != nil {
 		return err
 	}
@@ -110,7 +164,44 @@ func (c Config) ExportJSON() ([]byte, error) {
 }
 
 func (c *Config) ParseJSON(data []byte) error {
-	return json.Unmarshal(data, c)
+	if err := json.Unmarshal(data, c); err != nil {
+		return err
+	}
+	c.setDefault()
+	return nil
+}
+
+// setDefault sets the default values to the fields which are not in the config
+func (c *Config) setDefault() {
+	// the config created by older version has no upsertIgnoreColumns
+	if c.UpsertIgnoreColumns == nil {
+		c.UpsertIgnoreColumns = defaultUpsertIgnoreColumns
+	}
+}
+
+// GetVersionColumn returns the version column for optimistic locking of the table,
+// versionColumns of the table takes precedence over versionColumn
+func (c Config) GetVersionColumn(tableName string) string {
+	if column, ok := c.VersionColumns[tableName]; ok {
+		return column
+	}
+	return c.VersionColumn
+}
+
+// GetProtoGoPackage returns the Go package of the messages generated by protoc, {packageRoot}/pb by default
+func (c Config) GetProtoGoPackage() string {
+	if c.ProtoGoPackage != "" {
+		return c.ProtoGoPackage
+	}
+	return path.Join(c.PackageRoot, "pb")
+}
+
+// GetProtoPackage returns the package of the proto files, the last element of the Go package by default
+func (c Config) GetProtoPackage() string {
+	if c.ProtoPackage != "" {
+		return c.ProtoPackage
+	}
+	return path.Base(c.GetProtoGoPackage())
 }
 
 func getPackageRoot() string {
@@ -0,0 +1,57 @@
+package dependency
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+// DSNOptions returns the options to connect to MySQL with the credentials, the environment variables are expanded
+func (mc MysqlConfig) DSNOptions() (mysql.DSNOptions, error) {
+	var opts mysql.DSNOptions
+	mc, err := mc.ExpandEnv()
+	if err != nil {
+		return opts, err
+	}
+	user, password, err := mc.Credentials()
+	if err != nil {
+		return opts, err
+	}
+	connectTimeout, err := parseTimeout("connectTimeout", mc.ConnectTimeout)
+	if err != nil {
+		return opts, err
+	}
+	readTimeout, err := parseTimeout("readTimeout", mc.ReadTimeout)
+	if err != nil {
+		return opts, err
+	}
+	return mysql.DSNOptions{
+		DSN:            mc.DSN,
+		Host:           mc.Host,
+		Port:           mc.Port,
+		Socket:         mc.Socket,
+		User:           user,
+		Password:       password,
+		DBName:         mc.DbName,
+		TLSMode:        mc.TLSMode,
+		TLSCA:          mc.TLSCA,
+		TLSCert:        mc.TLSCert,
+		TLSKey:         mc.TLSKey,
+		ConnectTimeout: connectTimeout,
+		ReadTimeout:    readTimeout,
+		Params:         mc.Params,
+	}, nil
+}
+
+// parseTimeout parses the duration of the timeout, zero if empty
+func parseTimeout(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s", name)
+	}
+	return d, nil
+}
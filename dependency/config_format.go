@@ -0,0 +1,240 @@
+package dependency
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// the formats of the config file, which is detected by the extension
+const (
+	ConfigFormatJSON = "json"
+	ConfigFormatYAML = "yaml"
+	ConfigFormatTOML = "toml"
+)
+
+// GetConfigFormat returns the format of the config file by the extension, json by default
+func GetConfigFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ConfigFormatYAML
+	case ".toml":
+		return ConfigFormatTOML
+	default:
+		return ConfigFormatJSON
+	}
+}
+
+// Parse parses the config of the format
+func (c *Config) Parse(data []byte, format string) error {
+	switch format {
+	case ConfigFormatYAML:
+		return c.ParseYAML(data)
+	case ConfigFormatTOML:
+		return c.ParseTOML(data)
+	default:
+		return c.ParseJSON(data)
+	}
+}
+
+func (c *Config) ParseYAML(data []byte) error {
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return err
+	}
+	c.setDefault()
+	return nil
+}
+
+func (c *Config) ParseTOML(data []byte) error {
+	if err := toml.Unmarshal(data, c); err != nil {
+		return err
+	}
+	c.setDefault()
+	return nil
+}
+
+func (c Config) ExportYAML() ([]byte, error) {
+	buff := bytes.NewBuffer([]byte{})
+	enc := yaml.NewEncoder(buff)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+func (c Config) ExportTOML() ([]byte, error) {
+	buff := bytes.NewBuffer([]byte{})
+	enc := toml.NewEncoder(buff)
+	enc.Indent = ""
+	if err := enc.Encode(c); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// Export returns the config of the format, the comments of original are kept in yaml and toml
+func (c Config) Export(format string, original []byte) ([]byte, error) {
+	switch format {
+	case ConfigFormatYAML:
+		b, err := c.ExportYAML()
+		if err != nil || len(original) == 0 {
+			return b, err
+		}
+		return keepYAMLComments(b, original)
+	case ConfigFormatTOML:
+		b, err := c.ExportTOML()
+		if err != nil || len(original) == 0 {
+			return b, err
+		}
+		return keepTOMLComments(b, original), nil
+	default:
+		return c.ExportJSON()
+	}
+}
+
+// keepYAMLComments copies the comments of the same keys in original to b
+func keepYAMLComments(b, original []byte) ([]byte, error) {
+	var src, dst yaml.Node
+	if err := yaml.Unmarshal(original, &src); err != nil {
+		return nil, errors.Wrap(err, "failed to parse original yaml")
+	}
+	if err := yaml.Unmarshal(b, &dst); err != nil {
+		return nil, err
+	}
+	copyYAMLComments(&dst, &src)
+	buff := bytes.NewBuffer([]byte{})
+	enc := yaml.NewEncoder(buff)
+	enc.SetIndent(2)
+	if err := enc.Encode(&dst); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+func copyYAMLComments(dst, src *yaml.Node) {
+	if dst.Kind != src.Kind {
+		return
+	}
+	dst.HeadComment = src.HeadComment
+	dst.LineComment = src.LineComment
+	dst.FootComment = src.FootComment
+	switch dst.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for i := 0; i < len(dst.Content) && i < len(src.Content); i++ {
+			copyYAMLComments(dst.Content[i], src.Content[i])
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			for j := 0; j+1 < len(src.Content); j += 2 {
+				if dst.Content[i].Value == src.Content[j].Value {
+					copyYAMLComments(dst.Content[i], src.Content[j])
+					copyYAMLComments(dst.Content[i+1], src.Content[j+1])
+					break
+				}
+			}
+		}
+	}
+}
+
+// tomlComment is the comments of a key or a table header of toml
+type tomlComment struct {
+	head   []string
+	inline string
+}
+
+// keepTOMLComments copies the comments of the same keys and table headers in original to b.
+// The comment lines above and the inline comment are kept, since toml package does not keep the comments.
+func keepTOMLComments(b, original []byte) []byte {
+	comments := map[string]tomlComment{}
+	var head []string
+	walkTOMLLines(original, func(id, line string) {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			head = append(head, trimmed)
+		case id != "":
+			comments[id] = tomlComment{head: head, inline: tomlInlineComment(line)}
+			head = nil
+		}
+	})
+	foot := head
+
+	buff := bytes.NewBuffer([]byte{})
+	walkTOMLLines(b, func(id, line string) {
+		if comment, ok := comments[id]; ok && id != "" {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			for _, h := range comment.head {
+				buff.WriteString(indent + h + "\n")
+			}
+			if comment.inline != "" {
+				line += " " + comment.inline
+			}
+		}
+		buff.WriteString(line + "\n")
+	})
+	for _, f := range foot {
+		buff.WriteString(f + "\n")
+	}
+	return buff.Bytes()
+}
+
+// walkTOMLLines calls fn for each line with the id of the key or the table header, the id is empty if the line is neither
+func walkTOMLLines(b []byte, fn func(id, line string)) {
+	table := ""
+	counts := map[string]int{}
+	lines := strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		id := ""
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		case strings.HasPrefix(trimmed, "["):
+			header := normalizeTOMLKey(strings.TrimSpace(strings.SplitN(trimmed, "]", 2)[0] + "]"))
+			if strings.HasPrefix(trimmed, "[[") {
+				header = normalizeTOMLKey(strings.SplitN(trimmed, "]]", 2)[0] + "]]")
+			}
+			counts[header]++
+			table = header + "#" + strconv.Itoa(counts[header])
+			id = table
+		case strings.Contains(trimmed, "="):
+			id = table + "." + normalizeTOMLKey(strings.SplitN(trimmed, "=", 2)[0])
+		}
+		fn(id, line)
+	}
+}
+
+// normalizeTOMLKey removes the spaces and the quotes of the key, e.g. "users.id" is users.id
+func normalizeTOMLKey(key string) string {
+	return strings.NewReplacer(" ", "", "\t", "", `"`, "", "'", "").Replace(key)
+}
+
+// tomlInlineComment returns the comment at the end of the line, which is out of the strings
+func tomlInlineComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == '"' && c == '\\':
+			i++ // escaped character
+		case quote == 0 && c == '#':
+			return strings.TrimSpace(line[i:])
+		}
+	}
+	return ""
+}
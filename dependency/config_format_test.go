@@ -0,0 +1,96 @@
+package dependency
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUtil_GetConfigFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(ConfigFormatJSON, GetConfigFormat("config.json"))
+	assert.Equal(ConfigFormatJSON, GetConfigFormat("config"))
+	assert.Equal(ConfigFormatYAML, GetConfigFormat("config.yaml"))
+	assert.Equal(ConfigFormatYAML, GetConfigFormat("config.YML"))
+	assert.Equal(ConfigFormatTOML, GetConfigFormat("config.toml"))
+}
+
+func TestUtil_Parse(t *testing.T) {
+	assert := assert.New(t)
+
+	data := NewConfig("test-localhost", "3306", "test-user", "test-pass", "test-db")
+	data.CustomColumnType["users.id"] = &CustomColumnType{Type: "UserID", SampleValue: "1"}
+	data.VersionColumns = map[string]string{"users": "lock_version"}
+	for _, format := range []string{ConfigFormatJSON, ConfigFormatYAML, ConfigFormatTOML} {
+		b, err := data.Export(format, nil)
+		assert.NoError(err, format)
+
+		conf := Config{}
+		assert.NoError(conf.Parse(b, format), format)
+		assert.Equal(data, conf, format)
+	}
+
+	// default
+	conf := Config{}
+	assert.NoError(conf.ParseYAML([]byte("packageRoot: test\n")))
+	assert.Equal([]string{"created_at"}, conf.UpsertIgnoreColumns)
+	conf = Config{}
+	assert.NoError(conf.ParseTOML([]byte("packageRoot = \"test\"\n")))
+	assert.Equal([]string{"created_at"}, conf.UpsertIgnoreColumns)
+}
+
+func TestUtil_Write_keepComments(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+
+	tests := []struct {
+		name     string
+		original string
+		comments []string
+	}{
+		{"config.yaml", `# gendao config
+packageRoot: test
+customColumnTypes:
+  # the ids are typed to avoid mixing up
+  users.id:
+    type: UserID # defined in model/types.go
+`, []string{"# gendao config\n", "  # the ids are typed to avoid mixing up\n  users.id:\n", "type: UserID # defined in model/types.go\n"}},
+		{"config.toml", `# gendao config
+packageRoot = "test" # the package of the generated code
+
+# the ids are typed to avoid mixing up
+[customColumnTypes."users.id"]
+type = "UserID" # defined in "model/types.go"
+# end of config
+`, []string{"# gendao config\npackageRoot = \"test\" # the package of the generated code\n",
+			"# the ids are typed to avoid mixing up\n[customColumnTypes.\"users.id\"]\n",
+			"type = \"UserID\" # defined in \"model/types.go\"\n",
+			"# end of config\n"}},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name)
+		assert.NoError(ioutil.WriteFile(path, []byte(tt.original), 0644))
+
+		conf := Config{}
+		b, err := ioutil.ReadFile(path)
+		assert.NoError(err)
+		assert.NoError(conf.Parse(b, GetConfigFormat(path)))
+		conf.CustomColumnType["items.id"] = &CustomColumnType{Type: "ItemID"}
+		assert.NoError(conf.Write(path))
+
+		b, err = ioutil.ReadFile(path)
+		assert.NoError(err)
+		for _, comment := range tt.comments {
+			assert.Contains(string(b), comment, tt.name)
+		}
+		assert.Contains(string(b), "ItemID", tt.name)
+
+		written := Config{}
+		assert.NoError(written.Parse(b, GetConfigFormat(path)))
+		assert.Equal(conf.CustomColumnType, written.CustomColumnType, tt.name)
+	}
+}
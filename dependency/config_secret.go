@@ -0,0 +1,214 @@
+package dependency
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/suzujun/gendao/helper"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+// envReg is the reference to the environment variable in the config, e.g. ${DB_PASSWORD}
+var envReg = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// the option files of MySQL, which are read from the home directory if exists
+const (
+	defaultOptionFile    = ".my.cnf"
+	defaultLoginPathFile = ".mylogin.cnf"
+	// loginPathFileEnv overrides the path of .mylogin.cnf, same as mysql client
+	loginPathFileEnv = "MYSQL_TEST_LOGIN_FILE"
+	defaultLoginPath = "client"
+	// optionGroup is the group of the option file to read
+	optionGroup = "client"
+)
+
+// ExpandEnv returns the copy of the config, the references to the environment variables in the strings are replaced by the values.
+// The config itself keeps the references, so write it instead of the copy.
+// mysqlConfig except dbName is expanded by MysqlConfig.ExpandEnv on connecting,
+// so that the commands without the connection like gen do not require the credentials.
+// dbName is the database name of dsn if empty.
+func (c Config) ExpandEnv() (Config, error) {
+	// deep copy not to change the maps and the pointers of the config
+	var res Config
+	b, err := json.Marshal(c)
+	if err != nil {
+		return res, err
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return res, err
+	}
+	mc := res.MysqlConfig
+	res.MysqlConfig = MysqlConfig{DbName: mc.DbName}
+	var missing []string
+	expandEnvValue(reflect.ValueOf(&res).Elem(), &missing)
+	mc.DbName = res.MysqlConfig.DbName
+	if mc.DbName == "" {
+		mc.DbName = mc.dsnDbName()
+	}
+	res.MysqlConfig = mc
+	return res, missingEnvError(missing)
+}
+
+// ExpandEnv returns the copy of the config, the references to the environment variables in the strings are replaced by the values
+func (mc MysqlConfig) ExpandEnv() (MysqlConfig, error) {
+	res := mc
+	if mc.Params != nil {
+		res.Params = make(map[string]string, len(mc.Params))
+		for key, value := range mc.Params {
+			res.Params[key] = value
+		}
+	}
+	var missing []string
+	expandEnvValue(reflect.ValueOf(&res).Elem(), &missing)
+	return res, missingEnvError(missing)
+}
+
+// dsnDbName returns the database name of dsn, the unset environment variables in dsn are expanded to empty
+func (mc MysqlConfig) dsnDbName() string {
+	if mc.DSN == "" {
+		return ""
+	}
+	var missing []string
+	name, _ := mysql.DSNDBName(expandEnv(mc.DSN, &missing))
+	return name
+}
+
+func missingEnvError(missing []string) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	return errors.Errorf("environment variables are not set, [%s]", strings.Join(missing, ", "))
+}
+
+func expandEnvValue(v reflect.Value, missing *[]string) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(expandEnv(v.String(), missing))
+	case reflect.Ptr:
+		if !v.IsNil() {
+			expandEnvValue(v.Elem(), missing)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			expandEnvValue(v.Field(i), missing)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			expandEnvValue(v.Index(i), missing)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := v.MapIndex(key)
+			if elem.Kind() == reflect.String {
+				v.SetMapIndex(key, reflect.ValueOf(expandEnv(elem.String(), missing)))
+			} else {
+				expandEnvValue(elem, missing)
+			}
+		}
+	}
+}
+
+func expandEnv(s string, missing *[]string) string {
+	return envReg.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envReg.FindStringSubmatch(ref)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok && !helper.StringsContains(*missing, name) {
+			*missing = append(*missing, name)
+		}
+		return value
+	})
+}
+
+// Credentials returns the user and the password to connect to MySQL, which are resolved in this order.
+//
+//  1. user and password of dsn, the others are not read if dsn has both
+//  2. passwordEnv, the environment variable of the password
+//  3. passwordFile, the file of the password
+//  4. user and password of the config
+//  5. loginPath of .mylogin.cnf written by mysql_config_editor
+//  6. [client] of optionFile, ~/.my.cnf by default
+func (mc MysqlConfig) Credentials() (string, string, error) {
+	if mc.DSN != "" {
+		user, password, err := mysql.DSNCredentials(mc.DSN)
+		if err != nil {
+			return "", "", err
+		}
+		if user != "" && password != "" {
+			return user, password, nil
+		}
+	}
+	user, password := mc.User, mc.Password
+	switch {
+	case mc.PasswordEnv != "":
+		v, ok := os.LookupEnv(mc.PasswordEnv)
+		if !ok {
+			return "", "", errors.Errorf("environment variable of passwordEnv is not set, [%s]", mc.PasswordEnv)
+		}
+		password = v
+	case mc.PasswordFile != "":
+		b, err := helper.ReadFile(mc.PasswordFile)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to read passwordFile")
+		}
+		password = strings.TrimRight(string(b), "\r\n")
+	}
+	if user != "" && password != "" {
+		return user, password, nil
+	}
+	options, err := mc.readOptions()
+	if err != nil {
+		return "", "", err
+	}
+	for _, option := range options {
+		if user == "" && option["user"] != "" {
+			user = option["user"]
+		}
+		if password == "" && option["password"] != "" {
+			password = option["password"]
+		}
+	}
+	return user, password, nil
+}
+
+// readOptions returns the options of the login path and the option file in order of precedence
+func (mc MysqlConfig) readOptions() ([]map[string]string, error) {
+	home, _ := os.UserHomeDir()
+	var res []map[string]string
+
+	loginFile := os.Getenv(loginPathFileEnv)
+	if loginFile == "" && home != "" {
+		loginFile = filepath.Join(home, defaultLoginPathFile)
+	}
+	if loginFile != "" && helper.IsFileExist(loginFile) {
+		groups, err := mysql.ReadLoginPathFile(loginFile)
+		if err != nil {
+			return nil, err
+		}
+		loginPath := mc.LoginPath
+		if loginPath == "" {
+			loginPath = defaultLoginPath
+		}
+		res = append(res, groups[loginPath])
+	}
+
+	optionFile := mc.OptionFile
+	if optionFile == "" && home != "" {
+		optionFile = filepath.Join(home, defaultOptionFile)
+		if !helper.IsFileExist(optionFile) {
+			optionFile = ""
+		}
+	}
+	if optionFile != "" {
+		groups, err := mysql.ReadOptionFile(optionFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read optionFile")
+		}
+		res = append(res, groups[optionGroup])
+	}
+	return res, nil
+}
@@ -0,0 +1,105 @@
+package dependency
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUtil_ExpandEnv(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("GENDAO_TEST_PASSWORD", "secret")
+	t.Setenv("GENDAO_TEST_PKG", "github.com/foo/bar")
+
+	conf := NewConfig("", "", "", "${GENDAO_TEST_PASSWORD}", "test-db")
+	conf.OutputSourcePath = "${GENDAO_TEST_PKG}/src"
+	conf.CustomColumnType["users.id"] = &CustomColumnType{Type: "UserID", Package: "${GENDAO_TEST_PKG}/types"}
+	conf.VersionColumns = map[string]string{"users": "${GENDAO_TEST_PKG}"}
+
+	conf.MysqlConfig.DbName = "${GENDAO_TEST_PKG}"
+	conf.MysqlConfig.Params = map[string]string{"charset": "${GENDAO_TEST_PASSWORD}"}
+
+	res, err := conf.ExpandEnv()
+	assert.NoError(err)
+	assert.Equal("github.com/foo/bar/src", res.OutputSourcePath)
+	assert.Equal("github.com/foo/bar/types", res.CustomColumnType["users.id"].Package)
+	assert.Equal("github.com/foo/bar", res.VersionColumns["users"])
+	assert.Equal("github.com/foo/bar", res.MysqlConfig.DbName)
+	// the config keeps the references
+	assert.Equal("${GENDAO_TEST_PKG}/types", conf.CustomColumnType["users.id"].Package)
+
+	// mysqlConfig except dbName is expanded on connecting
+	assert.Equal("${GENDAO_TEST_PASSWORD}", res.MysqlConfig.Password)
+	mc, err := res.MysqlConfig.ExpandEnv()
+	assert.NoError(err)
+	assert.Equal("secret", mc.Password)
+	assert.Equal("secret", mc.Params["charset"])
+	assert.Equal("${GENDAO_TEST_PASSWORD}", res.MysqlConfig.Params["charset"])
+
+	// the unset variables of mysqlConfig are not required without the connection
+	conf.MysqlConfig.Host = "${GENDAO_TEST_UNSET}"
+	res, err = conf.ExpandEnv()
+	assert.NoError(err)
+	_, err = res.MysqlConfig.ExpandEnv()
+	assert.EqualError(err, "environment variables are not set, [GENDAO_TEST_UNSET]")
+	_, err = res.MysqlConfig.DSNOptions()
+	assert.EqualError(err, "environment variables are not set, [GENDAO_TEST_UNSET]")
+
+	// dbName is the database of dsn if empty, even if the password of dsn is not set
+	conf.MysqlConfig.DbName = ""
+	conf.MysqlConfig.DSN = "app:${GENDAO_TEST_UNSET}@tcp(db:3306)/app"
+	res, err = conf.ExpandEnv()
+	assert.NoError(err)
+	assert.Equal("app", res.MysqlConfig.DbName)
+	assert.Equal("", conf.MysqlConfig.DbName)
+
+	conf.OutputSourcePath = "${GENDAO_TEST_UNSET}/src"
+	_, err = conf.ExpandEnv()
+	assert.EqualError(err, "environment variables are not set, [GENDAO_TEST_UNSET]")
+}
+
+func TestUtil_Credentials(t *testing.T) {
+	assert := assert.New(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(loginPathFileEnv, "")
+	assert.NoError(ioutil.WriteFile(filepath.Join(home, defaultOptionFile), []byte("[client]\nuser=cnf-user\npassword=cnf-pass\n"), 0600))
+	passwordFile := filepath.Join(home, "password")
+	assert.NoError(ioutil.WriteFile(passwordFile, []byte("file-pass\n"), 0600))
+	t.Setenv("GENDAO_TEST_PASSWORD", "env-pass")
+
+	tests := []struct {
+		config   MysqlConfig
+		user     string
+		password string
+	}{
+		{MysqlConfig{User: "root", Password: "pass", PasswordEnv: "GENDAO_TEST_PASSWORD", PasswordFile: passwordFile}, "root", "env-pass"},
+		{MysqlConfig{User: "root", Password: "pass", PasswordFile: passwordFile}, "root", "file-pass"},
+		{MysqlConfig{User: "root", Password: "pass"}, "root", "pass"},
+		{MysqlConfig{User: "root"}, "root", "cnf-pass"},
+		{MysqlConfig{}, "cnf-user", "cnf-pass"},
+		{MysqlConfig{DSN: "app:dsn-pass@tcp(db:3306)/test", PasswordEnv: "GENDAO_TEST_PASSWORD"}, "app", "dsn-pass"},
+		{MysqlConfig{DSN: "app@tcp(db:3306)/test"}, "cnf-user", "cnf-pass"},
+	}
+	for _, tt := range tests {
+		user, password, err := tt.config.Credentials()
+		assert.NoError(err)
+		assert.Equal(tt.user, user)
+		assert.Equal(tt.password, password)
+	}
+
+	_, _, err := MysqlConfig{PasswordEnv: "GENDAO_TEST_UNSET"}.Credentials()
+	assert.EqualError(err, "environment variable of passwordEnv is not set, [GENDAO_TEST_UNSET]")
+	_, _, err = MysqlConfig{OptionFile: filepath.Join(home, "not-found.cnf")}.Credentials()
+	assert.Error(err)
+	// the option file is not read if dsn has the credentials
+	_, _, err = MysqlConfig{DSN: "app:dsn-pass@tcp(db:3306)/test", OptionFile: filepath.Join(home, "not-found.cnf")}.Credentials()
+	assert.NoError(err)
+	os.Remove(filepath.Join(home, defaultOptionFile))
+	user, password, err := MysqlConfig{}.Credentials()
+	assert.NoError(err)
+	assert.Equal("", user+password)
+}
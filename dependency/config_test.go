@@ -46,3 +46,44 @@ func TestUtil_ParseJSON(t *testing.T) {
 	assert.NoError(conf.ParseJSON(b))
 	assert.Equal(conf, data)
 }
+
+func TestUtil_ParseJSON_upsertIgnoreColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	// default
+	conf := Config{}
+	assert.NoError(conf.ParseJSON([]byte(`{"packageRoot": "test"}`)))
+	assert.Equal([]string{"created_at"}, conf.UpsertIgnoreColumns)
+
+	// empty
+	conf = Config{}
+	assert.NoError(conf.ParseJSON([]byte(`{"upsertIgnoreColumns": []}`)))
+	assert.Equal([]string{}, conf.UpsertIgnoreColumns)
+}
+
+func TestUtil_GetVersionColumn(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := Config{
+		VersionColumn:  "version",
+		VersionColumns: map[string]string{"users": "lock_version", "logs": ""},
+	}
+	assert.Equal("version", conf.GetVersionColumn("items"))
+	assert.Equal("lock_version", conf.GetVersionColumn("users"))
+	assert.Equal("", conf.GetVersionColumn("logs"))
+}
+
+func TestUtil_GetProtoPackage(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := Config{PackageRoot: "github.com/foo/bar"}
+	assert.Equal("github.com/foo/bar/pb", conf.GetProtoGoPackage())
+	assert.Equal("pb", conf.GetProtoPackage())
+
+	conf.ProtoGoPackage = "github.com/foo/api/userpb"
+	assert.Equal("github.com/foo/api/userpb", conf.GetProtoGoPackage())
+	assert.Equal("userpb", conf.GetProtoPackage())
+
+	conf.ProtoPackage = "foo.user.v1"
+	assert.Equal("foo.user.v1", conf.GetProtoPackage())
+}
@@ -0,0 +1,136 @@
+package dependency
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/suzujun/gendao/helper"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+// customColumnTypeKeyReg is the key of customColumnTypes, which is table.column
+var customColumnTypeKeyReg = regexp.MustCompile(`^\w+\.\w+$`)
+
+// Validate returns every problem of the config, which is checked against the template files in inputTemplatePath
+// and the columns of the tables, the keys of tables are the table names and the values are the column names.
+func (c Config) Validate(tables map[string][]string) []string {
+	var problems []string
+	add := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+	if c.MysqlConfig.DbName == "" && c.MysqlConfig.dsnDbName() == "" {
+		add("mysqlConfig.dbName is empty")
+	}
+	// the values referring to the unset environment variables are not checked, which are required only on connecting
+	mc, err := c.MysqlConfig.ExpandEnv()
+	if err != nil {
+		mc = c.MysqlConfig
+	}
+	checkFile := func(name, path string) {
+		if path != "" && !envReg.MatchString(path) && !helper.IsFileExist(path) {
+			add("mysqlConfig.%s %q is not found", name, path)
+		}
+	}
+	checkFile("passwordFile", mc.PasswordFile)
+	checkFile("optionFile", mc.OptionFile)
+	switch mc.TLSMode {
+	case "", mysql.TLSModeDisabled, mysql.TLSModeRequired, mysql.TLSModeSkipVerify, mysql.TLSModePreferred:
+	default:
+		if !envReg.MatchString(mc.TLSMode) {
+			add("mysqlConfig.tlsMode %q is not one of %s, %s, %s and %s", mc.TLSMode,
+				mysql.TLSModeDisabled, mysql.TLSModeRequired, mysql.TLSModeSkipVerify, mysql.TLSModePreferred)
+		}
+	}
+	checkFile("tlsCA", mc.TLSCA)
+	checkFile("tlsCert", mc.TLSCert)
+	checkFile("tlsKey", mc.TLSKey)
+	for _, f := range []struct{ name, value string }{
+		{"connectTimeout", mc.ConnectTimeout}, {"readTimeout", mc.ReadTimeout},
+	} {
+		if _, err := parseTimeout(f.name, f.value); err != nil && !envReg.MatchString(f.value) {
+			add("mysqlConfig.%s %q is not a duration like 10s", f.name, f.value)
+		}
+	}
+	switch c.JSONNaming {
+	case "", JSONNamingSnake, JSONNamingCamel, JSONNamingPascal:
+	default:
+		add("jsonNaming %q is not one of %s, %s and %s", c.JSONNaming, JSONNamingSnake, JSONNamingCamel, JSONNamingPascal)
+	}
+
+	// templates
+	exportNames := map[string]string{}
+	checkTemplates := func(field string, tmplFiles []TemplateFile, loop bool) {
+		for i, tf := range tmplFiles {
+			name := fmt.Sprintf("%s[%d]", field, i)
+			if tf.Name == "" {
+				add("%s has no name", name)
+			} else if path := filepath.Join(c.InputTemplatePath, tf.Name); !helper.IsFileExist(path) {
+				add("%s template file %q is not found in %q", name, tf.Name, c.InputTemplatePath)
+			}
+			if tf.ExportName == "" {
+				continue // the template called by the other templates
+			}
+			if loop && !strings.Contains(tf.ExportName, "{name}") {
+				add("%s exportName %q has no {name}, every table overwrites the same file", name, tf.ExportName)
+			} else if !loop && strings.Contains(tf.ExportName, "{name}") {
+				add("%s exportName %q has {name}, which is empty in templateByOnce", name, tf.ExportName)
+			}
+			path := filepath.Clean(tf.ExportName)
+			if other, ok := exportNames[path]; ok {
+				add("%s exportName %q is same as %s", name, tf.ExportName, other)
+			} else {
+				exportNames[path] = name
+			}
+		}
+	}
+	checkTemplates("templateByOnce", c.TemplateByOnce, false)
+	checkTemplates("templateToTableLoop", c.TemplateToTableLoop, true)
+
+	// tables and columns
+	hasColumn := func(table, column string) bool {
+		return helper.StringsContains(tables[table], column)
+	}
+	for _, name := range c.IgnoreTableNames {
+		if _, ok := tables[name]; !ok {
+			add("ignoreTableNames %q is not in the tables", name)
+		}
+	}
+	keys := make([]string, 0, len(c.CustomColumnType))
+	for key := range c.CustomColumnType {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		ct := c.CustomColumnType[key]
+		if !customColumnTypeKeyReg.MatchString(key) {
+			add("customColumnTypes key %q is not table.column", key)
+			continue
+		}
+		ss := strings.SplitN(key, ".", 2)
+		if _, ok := tables[ss[0]]; !ok {
+			add("customColumnTypes %q has no table %q", key, ss[0])
+		} else if !hasColumn(ss[0], ss[1]) {
+			add("customColumnTypes %q has no column %q in the table %q", key, ss[1], ss[0])
+		}
+		if ct == nil || ct.Type == "" {
+			add("customColumnTypes %q has no type", key)
+		}
+	}
+	versionTables := make([]string, 0, len(c.VersionColumns))
+	for table := range c.VersionColumns {
+		versionTables = append(versionTables, table)
+	}
+	sort.Strings(versionTables)
+	for _, table := range versionTables {
+		column := c.VersionColumns[table]
+		if _, ok := tables[table]; !ok {
+			add("versionColumns has no table %q", table)
+		} else if column != "" && !hasColumn(table, column) {
+			add("versionColumns %q has no column %q in the table", table, column)
+		}
+	}
+	return problems
+}
@@ -0,0 +1,70 @@
+package dependency
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUtil_Validate(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	for _, name := range []string{"dao.tpl", "dao_xxx.tpl", "part_method_name.tpl"} {
+		assert.NoError(ioutil.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	tables := map[string][]string{
+		"users":            {"id", "name", "lock_version"},
+		"goose_db_version": {"id"},
+	}
+
+	conf := NewConfig("", "", "", "", "test-db")
+	conf.InputTemplatePath = dir
+	conf.TemplateByOnce = []TemplateFile{{Name: "dao.tpl", ExportName: "dao/dao.go"}}
+	conf.TemplateToTableLoop = []TemplateFile{{Name: "dao_xxx.tpl", ExportName: "dao/{name}.go"}, {Name: "part_method_name.tpl"}}
+	conf.CustomColumnType["users.id"] = &CustomColumnType{Type: "UserID"}
+	conf.VersionColumns = map[string]string{"users": "lock_version"}
+	assert.Empty(conf.Validate(tables))
+
+	// the references to the unset environment variables are checked on connecting
+	conf.MysqlConfig.Password = "${GENDAO_TEST_UNSET}"
+	conf.MysqlConfig.PasswordFile = "${GENDAO_TEST_UNSET}/password"
+	assert.Empty(conf.Validate(tables))
+
+	// the database of dsn is used if dbName is empty
+	conf.MysqlConfig.DbName = ""
+	conf.MysqlConfig.DSN = "app:${GENDAO_TEST_UNSET}@tcp(db:3306)/test-db"
+	assert.Empty(conf.Validate(tables))
+
+	conf.MysqlConfig.DSN = "app@tcp(db:3306)/"
+	conf.MysqlConfig.TLSMode = "verify"
+	conf.MysqlConfig.TLSCA = filepath.Join(dir, "ca.pem")
+	conf.MysqlConfig.ReadTimeout = "10"
+	conf.JSONNaming = "kebab"
+	conf.TemplateByOnce = append(conf.TemplateByOnce, TemplateFile{Name: "model.tpl", ExportName: "model/{name}.go"})
+	conf.TemplateToTableLoop = append(conf.TemplateToTableLoop, TemplateFile{Name: "dao_xxx.tpl", ExportName: "dao/./dao.go"})
+	conf.IgnoreTableNames = append(conf.IgnoreTableNames, "logs")
+	conf.CustomColumnType["users.email"] = &CustomColumnType{Type: "Email"}
+	conf.CustomColumnType["items.id"] = &CustomColumnType{}
+	conf.CustomColumnType["id"] = &CustomColumnType{Type: "ID"}
+	conf.VersionColumns["items"] = "version"
+	assert.Equal([]string{
+		"mysqlConfig.dbName is empty",
+		`mysqlConfig.tlsMode "verify" is not one of false, true, skip-verify and preferred`,
+		`mysqlConfig.tlsCA "` + filepath.Join(dir, "ca.pem") + `" is not found`,
+		`mysqlConfig.readTimeout "10" is not a duration like 10s`,
+		`jsonNaming "kebab" is not one of snake, camel and pascal`,
+		`templateByOnce[1] template file "model.tpl" is not found in "` + dir + `"`,
+		`templateByOnce[1] exportName "model/{name}.go" has {name}, which is empty in templateByOnce`,
+		`templateToTableLoop[2] exportName "dao/./dao.go" has no {name}, every table overwrites the same file`,
+		`templateToTableLoop[2] exportName "dao/./dao.go" is same as templateByOnce[0]`,
+		`ignoreTableNames "logs" is not in the tables`,
+		`customColumnTypes key "id" is not table.column`,
+		`customColumnTypes "items.id" has no table "items"`,
+		`customColumnTypes "items.id" has no type`,
+		`customColumnTypes "users.email" has no column "email" in the table "users"`,
+		`versionColumns has no table "items"`,
+	}, conf.Validate(tables))
+}
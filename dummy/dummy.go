@@ -0,0 +1,228 @@
+// Package dummy generates random values which fit in the MySQL column types, for the dummy models.
+// The generated code calls these with the metadata of the column, e.g. the length and the precision.
+package dummy
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"gopkg.in/guregu/null.v3"
+)
+
+const (
+	letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// MaxLength limits the length of the strings and bytes, since text and blob can be up to 4GB
+	MaxLength = 1000
+	// nullRate is the rate of NULL of the null types
+	nullRate = 0.5
+)
+
+var (
+	minTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxTime = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC) // timestamp is up to 2038
+)
+
+// Null returns true at the rate of NULL
+func Null(r *rand.Rand) bool {
+	return r.Float64() < nullRate
+}
+
+// Int64 returns a random integer in [min, max]
+func Int64(r *rand.Rand, min, max int64) int64 {
+	if max <= min {
+		return min
+	}
+	span := uint64(max-min) + 1
+	if span == 0 {
+		return int64(r.Uint64()) // full range of int64
+	}
+	return min + int64(r.Uint64()%span)
+}
+
+// Uint64 returns a random unsigned integer in [min, max], including the values with high bit set
+func Uint64(r *rand.Rand, min, max uint64) uint64 {
+	if max <= min {
+		return min
+	}
+	span := max - min + 1
+	if span == 0 {
+		return r.Uint64() // full range of uint64
+	}
+	return min + r.Uint64()%span
+}
+
+// Float64 returns a random float in [min, max)
+func Float64(r *rand.Rand, min, max float64) float64 {
+	if max <= min {
+		return min
+	}
+	return min + r.Float64()*(max-min)
+}
+
+// Decimal returns a random decimal which has the digits within precision and scale, e.g. decimal(5,2) is in (-1000, 1000)
+func Decimal(r *rand.Rand, precision, scale int) float64 {
+	return decimal(r, precision, scale, false)
+}
+
+// UnsignedDecimal returns a random decimal same as Decimal but not negative, e.g. decimal(5,2) unsigned is in [0, 1000)
+func UnsignedDecimal(r *rand.Rand, precision, scale int) float64 {
+	return decimal(r, precision, scale, true)
+}
+
+func decimal(r *rand.Rand, precision, scale int, unsigned bool) float64 {
+	if scale > precision {
+		scale = precision
+	}
+	max := math.Pow10(precision - scale)
+	min := -max
+	if unsigned {
+		min = 0
+	}
+	unit := math.Pow10(scale)
+	return math.Trunc(Float64(r, min, max)*unit) / unit
+}
+
+// String returns a random string of ASCII letters, the length of which is in [min, max].
+// The length in characters is same as in bytes, so pass the smaller of the character and the byte length of the column.
+func String(r *rand.Rand, min, max int) string {
+	if max > MaxLength {
+		max = MaxLength
+	}
+	if min > max {
+		min = max
+	}
+	length := min
+	if max > min {
+		length += r.Intn(max - min + 1)
+	}
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// Bytes returns random bytes, the length of which is in [min, max]
+func Bytes(r *rand.Rand, min, max int) []byte {
+	if max > MaxLength {
+		max = MaxLength
+	}
+	if min > max {
+		min = max
+	}
+	length := min
+	if max > min {
+		length += r.Intn(max - min + 1)
+	}
+	b := make([]byte, length)
+	r.Read(b)
+	return b
+}
+
+// Enum returns one of the values of enum
+func Enum(r *rand.Rand, values ...string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[r.Intn(len(values))]
+}
+
+// Set returns some of the values of set joined with comma
+func Set(r *rand.Rand, values ...string) string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if r.Intn(2) == 0 {
+			res = append(res, v)
+		}
+	}
+	return strings.Join(res, ",")
+}
+
+// Time returns a random time in UTC truncated to the fractional seconds precision of datetime, timestamp or time
+func Time(r *rand.Rand, precision int) time.Time {
+	t := minTime.Add(time.Duration(r.Int63n(int64(maxTime.Sub(minTime)))))
+	unit := time.Second
+	for i := 0; i < precision && i < 9; i++ {
+		unit /= 10
+	}
+	return t.Truncate(unit)
+}
+
+// Date returns a random date in UTC
+func Date(r *rand.Rand) time.Time {
+	return Time(r, 0).Truncate(24 * time.Hour)
+}
+
+// NullInt64 returns NULL or Int64
+func NullInt64(r *rand.Rand, min, max int64) null.Int {
+	if Null(r) {
+		return null.Int{}
+	}
+	return null.IntFrom(Int64(r, min, max))
+}
+
+// NullFloat64 returns NULL or Float64
+func NullFloat64(r *rand.Rand, min, max float64) null.Float {
+	if Null(r) {
+		return null.Float{}
+	}
+	return null.FloatFrom(Float64(r, min, max))
+}
+
+// NullDecimal returns NULL or Decimal
+func NullDecimal(r *rand.Rand, precision, scale int) null.Float {
+	if Null(r) {
+		return null.Float{}
+	}
+	return null.FloatFrom(Decimal(r, precision, scale))
+}
+
+// NullUnsignedDecimal returns NULL or UnsignedDecimal
+func NullUnsignedDecimal(r *rand.Rand, precision, scale int) null.Float {
+	if Null(r) {
+		return null.Float{}
+	}
+	return null.FloatFrom(UnsignedDecimal(r, precision, scale))
+}
+
+// NullString returns NULL or String
+func NullString(r *rand.Rand, min, max int) null.String {
+	if Null(r) {
+		return null.String{}
+	}
+	return null.StringFrom(String(r, min, max))
+}
+
+// NullEnum returns NULL or Enum
+func NullEnum(r *rand.Rand, values ...string) null.String {
+	if Null(r) {
+		return null.String{}
+	}
+	return null.StringFrom(Enum(r, values...))
+}
+
+// NullSet returns NULL or Set
+func NullSet(r *rand.Rand, values ...string) null.String {
+	if Null(r) {
+		return null.String{}
+	}
+	return null.StringFrom(Set(r, values...))
+}
+
+// NullTime returns NULL or Time
+func NullTime(r *rand.Rand, precision int) null.Time {
+	if Null(r) {
+		return null.Time{}
+	}
+	return null.TimeFrom(Time(r, precision))
+}
+
+// NullDate returns NULL or Date
+func NullDate(r *rand.Rand) null.Time {
+	if Null(r) {
+		return null.Time{}
+	}
+	return null.TimeFrom(Date(r))
+}
@@ -0,0 +1,88 @@
+package dummy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummy_Int64(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := Int64(r, -128, 127)
+		assert.True(v >= -128 && v <= 127, v)
+	}
+	assert.Equal(int64(5), Int64(r, 5, 5))
+	negative := false
+	for i := 0; i < 100; i++ {
+		negative = negative || Int64(r, math.MinInt64, math.MaxInt64) < 0
+	}
+	assert.True(negative)
+}
+
+func TestDummy_Uint64(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		assert.True(Uint64(r, 10, 255) >= 10)
+		assert.True(Uint64(r, 10, 255) <= 255)
+	}
+	highBit := false
+	for i := 0; i < 100; i++ {
+		highBit = highBit || Uint64(r, 0, math.MaxUint64) > math.MaxInt64
+	}
+	assert.True(highBit)
+}
+
+func TestDummy_Decimal(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := Decimal(r, 5, 2)
+		assert.True(v > -1000 && v < 1000, v)
+		assert.InDelta(math.Round(v*100), v*100, 1e-6, v)
+	}
+	for i := 0; i < 1000; i++ {
+		v := UnsignedDecimal(r, 5, 2)
+		assert.True(v >= 0 && v < 1000, v)
+		assert.InDelta(math.Round(v*100), v*100, 1e-6, v)
+	}
+}
+
+func TestDummy_String(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := String(r, 3, 10)
+		assert.True(len(v) >= 3 && len(v) <= 10, v)
+		assert.Equal(len(v), utf8.RuneCountInString(v))
+	}
+	assert.True(len(String(r, 0, 100000)) <= MaxLength)
+	assert.Len(String(r, 100000, 100000), MaxLength)
+	assert.Equal("", String(r, 0, 0))
+}
+
+func TestDummy_Time(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := Time(r, 3)
+		assert.Equal(0, v.Nanosecond()%1000000, v)
+		assert.True(!v.Before(minTime) && v.Before(maxTime), v)
+		d := Date(r)
+		assert.Equal(0, d.Hour()+d.Minute()+d.Second()+d.Nanosecond(), d)
+	}
+}
+
+func TestDummy_reproducible(t *testing.T) {
+	assert := assert.New(t)
+	values := func() []interface{} {
+		r := rand.New(rand.NewSource(42))
+		return []interface{}{Int64(r, 0, 100), String(r, 1, 10), NullTime(r, 0), Enum(r, "a", "b"), NullDecimal(r, 10, 2)}
+	}
+	assert.Equal(values(), values())
+}
@@ -50,6 +50,39 @@ func (mc Column) Unique() bool {
 	return mc.ColumnKey == "UNI"
 }
 
+// EnumValues returns the values of enum or set column, e.g. "enum('a','b')" returns ["a", "b"]
+func (mc Column) EnumValues() []string {
+	if mc.DataType != "enum" && mc.DataType != "set" {
+		return nil
+	}
+	start := strings.Index(mc.ColumnType, "(")
+	end := strings.LastIndex(mc.ColumnType, ")")
+	if start < 0 || end < start {
+		return nil
+	}
+	var values []string
+	var value []rune
+	quoted := false
+	runes := []rune(mc.ColumnType[start+1 : end])
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\'' && quoted && i+1 < len(runes) && runes[i+1] == '\'':
+			value = append(value, c) // escaped quote
+			i++
+		case c == '\'':
+			quoted = !quoted
+			if !quoted {
+				values = append(values, string(value))
+				value = value[:0]
+			}
+		case quoted:
+			value = append(value, c)
+		}
+	}
+	return values
+}
+
 func (mc Column) DataTypeRange() dataTypeRange {
 	unsigned := mc.Unsigned()
 	switch mc.DataType {
@@ -11,22 +11,20 @@ import (
 
 type (
 	Connection struct {
-		host     string
-		port     string
-		user     string
-		password string
-		dbname   string
-		db       *sql.DB
+		dsn    string
+		dbname string
+		db     *sql.DB
 	}
 )
 
-func NewConnection(host, port, user, password, dbname string, close bool) (*Connection, error) {
+func NewConnection(opts DSNOptions, close bool) (*Connection, error) {
+	cfg, err := opts.Config()
+	if err != nil {
+		return nil, err
+	}
 	con := Connection{
-		host:     host,
-		port:     port,
-		user:     user,
-		password: password,
-		dbname:   dbname,
+		dsn:    cfg.FormatDSN(),
+		dbname: cfg.DBName,
 	}
 	if err := con.Open(); err != nil {
 		return nil, err
@@ -42,7 +40,7 @@ func (con *Connection) Open() error {
 		if con.dbname == "" {
 			return errors.New("No database name selected in config")
 		}
-		db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", con.user, con.password, con.host, con.port, con.dbname))
+		db, err := sql.Open("mysql", con.dsn)
 		if err != nil {
 			return err
 		}
@@ -54,6 +52,11 @@ func (con *Connection) Open() error {
 	return nil
 }
 
+// DB returns the opened database
+func (con *Connection) DB() *sql.DB {
+	return con.db
+}
+
 func (con *Connection) Close() error {
 	if con.db == nil {
 		return nil
@@ -92,9 +95,14 @@ func (con *Connection) GetTable(tableName string) (*Table, error) {
 	if err != nil {
 		return nil, err
 	}
+	foreignKeys, err := con.GetForeignKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
 	mt := Table{}
 	mt.Columns = columns
 	mt.Indexes = indexes
+	mt.ForeignKeys = foreignKeys
 	if len(columns) > 0 {
 		mt.Catalog = columns[0].TableCatalog
 		mt.Schema = columns[0].TableSchema
@@ -229,3 +237,37 @@ order by SORT_NUMBER, INDEX_NAME, SEQ_IN_INDEX
 	}
 	return result, nil
 }
+
+func (con *Connection) GetForeignKeys(tname string) ([]ForeignKey, error) {
+
+	if con.db == nil {
+		return nil, errors.New("database is closed")
+	}
+	rows, err := con.db.Query(`
+select CONSTRAINT_NAME, TABLE_NAME, COLUMN_NAME, ORDINAL_POSITION,
+  REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
+from information_schema.KEY_COLUMN_USAGE
+where TABLE_SCHEMA = ?
+and TABLE_NAME = ?
+and REFERENCED_TABLE_NAME is not null
+order by CONSTRAINT_NAME, ORDINAL_POSITION
+`, con.dbname, tname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []ForeignKey{}
+	for rows.Next() {
+		var fk ForeignKey
+		err = rows.Scan(
+			&fk.ConstraintName, &fk.TableName, &fk.ColumnName, &fk.OrdinalPosition,
+			&fk.ReferencedTableName, &fk.ReferencedColumnName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, fk)
+	}
+	return result, rows.Err()
+}
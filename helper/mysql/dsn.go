@@ -0,0 +1,159 @@
+package mysql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"time"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	"github.com/suzujun/gendao/helper"
+)
+
+// the modes of TLS, which are same as the tls parameter of the driver
+const (
+	TLSModeDisabled   = "false"
+	TLSModeRequired   = "true"
+	TLSModeSkipVerify = "skip-verify"
+	TLSModePreferred  = "preferred"
+)
+
+// tlsConfigName is the name of the TLS config registered to the driver with the certificate files
+const tlsConfigName = "gendao"
+
+// DSNOptions is the options to connect to MySQL.
+// DSN is the base of the options, and the other options are prior to it if set,
+// except that the address and the credentials of DSN are prior to Host, Port, Socket, User and Password.
+type DSNOptions struct {
+	DSN            string
+	Host           string
+	Port           string
+	Socket         string
+	User           string
+	Password       string
+	DBName         string
+	TLSMode        string
+	TLSCA          string
+	TLSCert        string
+	TLSKey         string
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration
+	Params         map[string]string
+}
+
+// DSNCredentials returns the user and the password in dsn
+func DSNCredentials(dsn string) (string, string, error) {
+	cfg, err := driver.ParseDSN(dsn)
+	if err != nil {
+		return "", "", errors.Wrap(err, "invalid dsn")
+	}
+	return cfg.User, cfg.Passwd, nil
+}
+
+// DSNDBName returns the database name in dsn
+func DSNDBName(dsn string) (string, error) {
+	cfg, err := driver.ParseDSN(dsn)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid dsn")
+	}
+	return cfg.DBName, nil
+}
+
+// Config returns the config of the driver, the TLS config is registered to the driver if the certificate files are set
+func (o DSNOptions) Config() (*driver.Config, error) {
+	var cfg *driver.Config
+	if o.DSN != "" {
+		var err error
+		if cfg, err = driver.ParseDSN(o.DSN); err != nil {
+			return nil, errors.Wrap(err, "invalid dsn")
+		}
+		if cfg.User == "" {
+			cfg.User = o.User
+		}
+		if cfg.Passwd == "" {
+			cfg.Passwd = o.Password
+		}
+	} else {
+		cfg = driver.NewConfig()
+		cfg.User = o.User
+		cfg.Passwd = o.Password
+		if o.Socket != "" {
+			cfg.Net = "unix"
+			cfg.Addr = o.Socket
+		} else {
+			cfg.Net = "tcp"
+			cfg.Addr = net.JoinHostPort(o.Host, o.Port)
+		}
+	}
+	if o.DBName != "" {
+		cfg.DBName = o.DBName
+	}
+	if o.ConnectTimeout > 0 {
+		cfg.Timeout = o.ConnectTimeout
+	}
+	if o.ReadTimeout > 0 {
+		cfg.ReadTimeout = o.ReadTimeout
+	}
+	for key, value := range o.Params {
+		if cfg.Params == nil {
+			cfg.Params = map[string]string{}
+		}
+		cfg.Params[key] = value
+	}
+
+	switch o.TLSMode {
+	case "", TLSModeDisabled, TLSModeRequired, TLSModeSkipVerify, TLSModePreferred:
+	default:
+		return nil, errors.Errorf("invalid tlsMode, [%s]", o.TLSMode)
+	}
+	if o.TLSCA == "" && o.TLSCert == "" && o.TLSKey == "" {
+		if o.TLSMode != "" {
+			cfg.TLSConfig = o.TLSMode
+		}
+		return cfg, nil
+	}
+	tlsConfig, err := o.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := driver.RegisterTLSConfig(tlsConfigName, tlsConfig); err != nil {
+		return nil, err
+	}
+	cfg.TLSConfig = tlsConfigName
+	return cfg, nil
+}
+
+// tlsConfig returns the TLS config with the certificate files, the server name is set by the driver
+func (o DSNOptions) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	switch o.TLSMode {
+	case "", TLSModeRequired:
+	case TLSModeSkipVerify:
+		tlsConfig.InsecureSkipVerify = true
+	default:
+		return nil, errors.Errorf("tlsMode %s can not be used with the certificate files", o.TLSMode)
+	}
+	if o.TLSCA != "" {
+		b, err := helper.ReadFile(o.TLSCA)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read tlsCA")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, errors.Errorf("no certificate in tlsCA, [%s]", o.TLSCA)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if o.TLSCert != "" || o.TLSKey != "" {
+		if o.TLSCert == "" || o.TLSKey == "" {
+			return nil, errors.New("both tlsCert and tlsKey are required")
+		}
+		cert, err := tls.LoadX509KeyPair(o.TLSCert, o.TLSKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load tlsCert and tlsKey")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,137 @@
+package mysql
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDSN_Config(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		opts DSNOptions
+		dsn  string
+	}{
+		{
+			DSNOptions{Host: "localhost", Port: "3306", User: "root", Password: "p@ss:/word", DBName: "test"},
+			"root:p@ss:/word@tcp(localhost:3306)/test",
+		},
+		{
+			DSNOptions{Host: "::1", Port: "3306", User: "root", DBName: "test", TLSMode: TLSModeSkipVerify,
+				ConnectTimeout: 5 * time.Second, ReadTimeout: time.Minute, Params: map[string]string{"charset": "utf8mb4"}},
+			"root@tcp([::1]:3306)/test?readTimeout=1m0s&timeout=5s&tls=skip-verify&charset=utf8mb4",
+		},
+		{
+			DSNOptions{Host: "localhost", Port: "3306", Socket: "/var/run/mysqld/mysqld.sock", User: "root", DBName: "test"},
+			"root@unix(/var/run/mysqld/mysqld.sock)/test",
+		},
+		{
+			DSNOptions{DSN: "app:secret@tcp(db:3307)/app?parseTime=true", Host: "localhost", User: "root", Password: "pass", DBName: "test"},
+			"app:secret@tcp(db:3307)/test?parseTime=true",
+		},
+		{
+			DSNOptions{DSN: "tcp(db:3307)/app", User: "root", Password: "pass"},
+			"root:pass@tcp(db:3307)/app",
+		},
+	}
+	for _, tt := range tests {
+		cfg, err := tt.opts.Config()
+		assert.NoError(err)
+		dsn := cfg.FormatDSN()
+		assert.Equal(tt.dsn, dsn)
+		// the driver reads the same config
+		parsed, err := driver.ParseDSN(dsn)
+		assert.NoError(err)
+		assert.Equal(cfg.Passwd, parsed.Passwd)
+		assert.Equal(cfg.DBName, parsed.DBName)
+	}
+
+	_, err := DSNOptions{DSN: "root@tcp(localhost:3306)"}.Config()
+	assert.Error(err)
+	_, err = DSNOptions{TLSMode: "verify"}.Config()
+	assert.EqualError(err, "invalid tlsMode, [verify]")
+}
+
+func TestDSN_DSNDBName(t *testing.T) {
+	assert := assert.New(t)
+
+	name, err := DSNDBName("app:secret@tcp(db:3307)/app?parseTime=true")
+	assert.NoError(err)
+	assert.Equal("app", name)
+	name, err = DSNDBName("app@tcp(db:3307)/")
+	assert.NoError(err)
+	assert.Empty(name)
+	_, err = DSNDBName("app@tcp(db:3307)")
+	assert.Error(err)
+}
+
+func TestDSN_ConfigTLS(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	ca, cert, key := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeCertificate(t, cert, key)
+	assert.NoError(ioutil.WriteFile(ca, mustReadFile(t, cert), 0600))
+
+	cfg, err := DSNOptions{Host: "db", Port: "3306", DBName: "test", TLSCA: ca, TLSCert: cert, TLSKey: key}.Config()
+	assert.NoError(err)
+	assert.Equal("tcp(db:3306)/test?tls=gendao", cfg.FormatDSN())
+	_, err = driver.ParseDSN(cfg.FormatDSN())
+	assert.NoError(err)
+
+	_, err = DSNOptions{DBName: "test", TLSMode: TLSModePreferred, TLSCA: ca}.Config()
+	assert.EqualError(err, "tlsMode preferred can not be used with the certificate files")
+	_, err = DSNOptions{DBName: "test", TLSCert: cert}.Config()
+	assert.EqualError(err, "both tlsCert and tlsKey are required")
+	_, err = DSNOptions{DBName: "test", TLSCA: key}.Config()
+	assert.EqualError(err, "no certificate in tlsCA, ["+key+"]")
+}
+
+// writeCertificate writes the self-signed certificate and the key
+func writeCertificate(t *testing.T, certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "db"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
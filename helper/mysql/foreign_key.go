@@ -0,0 +1,12 @@
+package mysql
+
+type (
+	ForeignKey struct {
+		ConstraintName       string `json:"constraintName" db:"CONSTRAINT_NAME"`
+		TableName            string `json:"tableName" db:"TABLE_NAME"`
+		ColumnName           string `json:"columnName" db:"COLUMN_NAME"`
+		OrdinalPosition      uint   `json:"ordinalPosition" db:"ORDINAL_POSITION"`
+		ReferencedTableName  string `json:"referencedTableName" db:"REFERENCED_TABLE_NAME"`
+		ReferencedColumnName string `json:"referencedColumnName" db:"REFERENCED_COLUMN_NAME"`
+	}
+)
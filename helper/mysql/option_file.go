@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/suzujun/gendao/helper"
+)
+
+// OptionGroups is the options of MySQL option file by the group, e.g. options["client"]["password"]
+type OptionGroups map[string]map[string]string
+
+// loginFileKeyOffset is the offset of the key in .mylogin.cnf, the first 4 bytes are unused
+const (
+	loginFileKeyOffset = 4
+	loginFileKeyLength = 20
+)
+
+// ReadOptionFile reads the option file of MySQL like ~/.my.cnf
+func ReadOptionFile(path string) (OptionGroups, error) {
+	b, err := helper.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseOptions(b), nil
+}
+
+// ReadLoginPathFile reads .mylogin.cnf written by mysql_config_editor, which is obfuscated by AES-128-ECB.
+// The groups are the login paths, e.g. client.
+func ReadLoginPathFile(path string) (OptionGroups, error) {
+	b, err := helper.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < loginFileKeyOffset+loginFileKeyLength {
+		return nil, errors.Errorf("invalid login path file, [%s]", path)
+	}
+	// the key of AES is folded from 20 bytes to 16 bytes by xor
+	key := make([]byte, aes.BlockSize)
+	for i, c := range b[loginFileKeyOffset : loginFileKeyOffset+loginFileKeyLength] {
+		key[i%aes.BlockSize] ^= c
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	plain := bytes.NewBuffer([]byte{})
+	for rest := b[loginFileKeyOffset+loginFileKeyLength:]; len(rest) > 0; {
+		// each line is encrypted with the length in little endian
+		if len(rest) < 4 {
+			return nil, errors.Errorf("invalid login path file, [%s]", path)
+		}
+		length := int(binary.LittleEndian.Uint32(rest))
+		rest = rest[4:]
+		if length == 0 || length > len(rest) || length%aes.BlockSize != 0 {
+			return nil, errors.Errorf("invalid login path file, [%s]", path)
+		}
+		line := make([]byte, length)
+		for i := 0; i < length; i += aes.BlockSize {
+			block.Decrypt(line[i:i+aes.BlockSize], rest[i:i+aes.BlockSize])
+		}
+		rest = rest[length:]
+		// remove the padding of PKCS#7
+		if pad := int(line[length-1]); pad > 0 && pad <= aes.BlockSize {
+			line = line[:length-pad]
+		}
+		plain.Write(line)
+	}
+	return parseOptions(plain.Bytes()), nil
+}
+
+// parseOptions parses the options, the names are normalized to use underscore instead of dash
+func parseOptions(b []byte) OptionGroups {
+	groups := OptionGroups{}
+	var group map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';' || line[0] == '!': // comment or !include
+		case line[0] == '[' && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if groups[name] == nil {
+				groups[name] = map[string]string{}
+			}
+			group = groups[name]
+		case group != nil:
+			kv := strings.SplitN(line, "=", 2)
+			name := strings.Replace(strings.TrimSpace(kv[0]), "-", "_", -1)
+			value := ""
+			if len(kv) == 2 {
+				value = optionValue(strings.TrimSpace(kv[1]))
+			}
+			group[name] = value
+		}
+	}
+	return groups
+}
+
+// optionValue returns the value without the quotes, or without the comment if not quoted
+func optionValue(v string) string {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') {
+		for i := 1; i < len(v); i++ {
+			switch v[i] {
+			case '\\':
+				i++ // escaped character
+			case v[0]:
+				return strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\'`, `'`, `\n`, "\n", `\t`, "\t").Replace(v[1:i])
+			}
+		}
+	}
+	if i := strings.IndexByte(v, '#'); i >= 0 {
+		v = strings.TrimSpace(v[:i])
+	}
+	return v
+}
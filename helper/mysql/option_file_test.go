@@ -0,0 +1,74 @@
+package mysql
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionFile_ReadOptionFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "my.cnf")
+	assert.NoError(ioutil.WriteFile(path, []byte(`# comment
+!includedir /etc/mysql/conf.d/
+[client]
+user = app
+password = "p#ss \"word\""
+default-character-set = utf8mb4 # comment
+
+[mysqld]
+skip-grant-tables
+`), 0600))
+	groups, err := ReadOptionFile(path)
+	assert.NoError(err)
+	assert.Equal(OptionGroups{
+		"client": {"user": "app", "password": `p#ss "word"`, "default_character_set": "utf8mb4"},
+		"mysqld": {"skip_grant_tables": ""},
+	}, groups)
+}
+
+func TestOptionFile_ReadLoginPathFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), ".mylogin.cnf")
+	assert.NoError(ioutil.WriteFile(path, encryptLoginPathFile("[client]\n", "user = \"app\"\n", "password = \"secret\"\n", "[remote]\n", "host = \"db.example.com\"\n"), 0600))
+	groups, err := ReadLoginPathFile(path)
+	assert.NoError(err)
+	assert.Equal(OptionGroups{
+		"client": {"user": "app", "password": "secret"},
+		"remote": {"host": "db.example.com"},
+	}, groups)
+
+	assert.NoError(ioutil.WriteFile(path, []byte("short"), 0600))
+	_, err = ReadLoginPathFile(path)
+	assert.Error(err)
+}
+
+// encryptLoginPathFile encrypts the lines same as mysql_config_editor
+func encryptLoginPathFile(lines ...string) []byte {
+	buff := bytes.NewBuffer(make([]byte, loginFileKeyOffset))
+	rawKey := []byte("0123456789abcdefghij")
+	buff.Write(rawKey)
+	key := make([]byte, aes.BlockSize)
+	for i, c := range rawKey {
+		key[i%aes.BlockSize] ^= c
+	}
+	block, _ := aes.NewCipher(key)
+	for _, line := range lines {
+		pad := aes.BlockSize - len(line)%aes.BlockSize
+		plain := append([]byte(line), bytes.Repeat([]byte{byte(pad)}, pad)...)
+		cipher := make([]byte, len(plain))
+		for i := 0; i < len(plain); i += aes.BlockSize {
+			block.Encrypt(cipher[i:i+aes.BlockSize], plain[i:i+aes.BlockSize])
+		}
+		binary.Write(buff, binary.LittleEndian, uint32(len(cipher)))
+		buff.Write(cipher)
+	}
+	return buff.Bytes()
+}
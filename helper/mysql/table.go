@@ -1,22 +1,47 @@
 package mysql
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/suzujun/gendao/helper"
 )
 
+// TableJSONVersion is the format version of the table json written by WriteJSON.
+// Increment it and add the migration to tableJSONMigrations, when the format is changed.
+// The json without version is version 1, which has no foreign keys.
+const TableJSONVersion = 2
+
 type (
 	Table struct {
+		// Version is the format version of the json
+		Version int      `json:"version"`
 		Catalog string   `json:"catalog"`
 		Schema  string   `json:"schema"`
 		Name    string   `json:"name"`
 		Columns []Column `json:"columns"`
 		Indexes []Index  `json:"indexes"`
+		// ForeignKeys is empty in the json pulled by older version
+		ForeignKeys []ForeignKey `json:"foreignKeys"`
 	}
 )
 
+// tableJSONMigrations migrates the json of the version to the next version
+var tableJSONMigrations = map[int]func(map[string]interface{}){
+	1: func(m map[string]interface{}) {
+		if m["foreignKeys"] == nil {
+			m["foreignKeys"] = []interface{}{} // needs pull again to get the foreign keys
+		}
+	},
+}
+
 func (mt Table) WriteJSON(path string) error {
+	mt.Version = TableJSONVersion
 	jsonBytes, err := json.MarshalIndent(mt, "", "  ")
 	if err != nil {
 		return err
@@ -24,3 +49,255 @@ func (mt Table) WriteJSON(path string) error {
 	_, err = helper.CreateFile(path, jsonBytes)
 	return err
 }
+
+// ReadTableJSON reads the table json, which is validated by ValidateTableJSON
+func ReadTableJSON(path string) (Table, error) {
+	b, err := helper.ReadFile(path)
+	if err != nil {
+		return Table{}, err
+	}
+	if problems := ValidateTableJSON(b); len(problems) > 0 {
+		return Table{}, errors.Errorf("invalid table json, [%s]\n - %s", path, strings.Join(problems, "\n - "))
+	}
+	var mt Table
+	err = json.Unmarshal(b, &mt)
+	return mt, err
+}
+
+// tableJSONRule is the rule of an object in the table json
+type tableJSONRule struct {
+	// required is the keys required in the object, required of the schema
+	required []string
+	// nonEmpty is the keys of the strings and the arrays which must not be empty, minLength and minItems of the schema
+	nonEmpty []string
+}
+
+// tableJSONRules is the rules of the objects in the table json by the name in $defs of schema/table.schema.json,
+// and table is the root object. The test keeps them same as the schema.
+var tableJSONRules = map[string]tableJSONRule{
+	"table": {
+		required: []string{"version", "name", "columns"},
+		nonEmpty: []string{"name", "columns"},
+	},
+	"column": {
+		required: []string{"tableName", "columnName", "dataType", "columnType"},
+		nonEmpty: []string{"columnName", "dataType", "columnType"},
+	},
+	"index": {
+		required: []string{"IndexName", "ColumnName"},
+		nonEmpty: []string{"IndexName"},
+	},
+	"foreignKey": {
+		required: []string{"constraintName", "columnName", "referencedTableName", "referencedColumnName"},
+		nonEmpty: []string{"constraintName", "referencedTableName", "referencedColumnName"},
+	},
+}
+
+// tableJSONArrays is the arrays of the objects in the root object and the names of the objects in $defs
+var tableJSONArrays = []struct{ key, def string }{
+	{"columns", "column"},
+	{"indexes", "index"},
+	{"foreignKeys", "foreignKey"},
+}
+
+// ValidateTableJSON returns the problems of the table json by the rules of schema/table.schema.json,
+// and the problems of the references between the columns, the indexes and the foreign keys.
+// The problems are reported with the keys of the json.
+func ValidateTableJSON(b []byte) []string {
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return []string{jsonErrorMessage(b, err)}
+	}
+	version, err := tableJSONVersion(m)
+	if err != nil {
+		return []string{err.Error()}
+	} else if version < TableJSONVersion {
+		return []string{fmt.Sprintf("version %d is older than %d, run `gendao upgrade-json` to migrate it", version, TableJSONVersion)}
+	} else if version > TableJSONVersion {
+		return []string{fmt.Sprintf("version %d is newer than %d, update gendao to read it", version, TableJSONVersion)}
+	}
+	var mt Table
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&mt); err != nil {
+		return []string{jsonErrorMessage(b, err)}
+	}
+
+	// the types are checked by decoding, so the objects are the maps
+	problems := checkTableJSONRule("", m, tableJSONRules["table"])
+	for _, array := range tableJSONArrays {
+		items, _ := m[array.key].([]interface{})
+		for i, item := range items {
+			obj, _ := item.(map[string]interface{})
+			problems = append(problems, checkTableJSONRule(fmt.Sprintf("%s[%d].", array.key, i), obj, tableJSONRules[array.def])...)
+		}
+	}
+	return append(problems, mt.validateReferences()...)
+}
+
+// checkTableJSONRule returns the problems of the object, prefix is the path of the object
+func checkTableJSONRule(prefix string, obj map[string]interface{}, rule tableJSONRule) []string {
+	var problems []string
+	for _, key := range rule.required {
+		if obj[key] == nil {
+			problems = append(problems, fmt.Sprintf("%s%s is required", prefix, key))
+		}
+	}
+	for _, key := range rule.nonEmpty {
+		switch v := obj[key].(type) {
+		case string:
+			if v == "" {
+				problems = append(problems, fmt.Sprintf("%s%s must not be empty", prefix, key))
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				problems = append(problems, fmt.Sprintf("%s%s must not be empty", prefix, key))
+			}
+		}
+	}
+	return problems
+}
+
+// validateReferences returns the problems of the names which are not checked by the schema
+func (mt Table) validateReferences() []string {
+	var problems []string
+	columnMap := make(map[string]bool, len(mt.Columns))
+	for i, column := range mt.Columns {
+		if column.ColumnName != "" && columnMap[column.ColumnName] {
+			problems = append(problems, fmt.Sprintf("columns[%d].columnName %q is duplicated", i, column.ColumnName))
+		}
+		columnMap[column.ColumnName] = true
+		if column.TableName != mt.Name {
+			problems = append(problems, fmt.Sprintf("columns[%d].tableName %q is not the name %q", i, column.TableName, mt.Name))
+		}
+	}
+	for i, index := range mt.Indexes {
+		if index.ColumnName != "" && !columnMap[index.ColumnName] {
+			problems = append(problems, fmt.Sprintf("indexes[%d].ColumnName %q is not in the columns", i, index.ColumnName))
+		}
+	}
+	for i, fk := range mt.ForeignKeys {
+		if fk.ColumnName != "" && !columnMap[fk.ColumnName] {
+			problems = append(problems, fmt.Sprintf("foreignKeys[%d].columnName %q is not in the columns", i, fk.ColumnName))
+		}
+	}
+	return problems
+}
+
+// UpgradeTableJSON migrates the table json to the current version, and returns the version before migration
+func UpgradeTableJSON(b []byte) ([]byte, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return nil, 0, errors.New(jsonErrorMessage(b, err))
+	}
+	version, err := tableJSONVersion(m)
+	if err != nil {
+		return nil, 0, err
+	} else if version > TableJSONVersion {
+		return nil, version, errors.Errorf("version %d is newer than %d, update gendao to read it", version, TableJSONVersion)
+	}
+	for v := version; v < TableJSONVersion; v++ {
+		tableJSONMigrations[v](m)
+	}
+	m["version"] = TableJSONVersion
+	// marshal through Table to keep the order of the fields same as WriteJSON
+	mb, err := json.Marshal(m)
+	if err != nil {
+		return nil, version, err
+	}
+	var mt Table
+	if err := json.Unmarshal(mb, &mt); err != nil {
+		return nil, version, err
+	}
+	res, err := json.MarshalIndent(mt, "", "  ")
+	return res, version, err
+}
+
+// tableJSONVersion returns the version of the json, 1 if no version
+func tableJSONVersion(m map[string]interface{}) (int, error) {
+	v, ok := m["version"]
+	if !ok {
+		return 1, nil
+	}
+	var version int
+	switch n := v.(type) {
+	case float64:
+		if n != float64(int(n)) {
+			return 0, errors.Errorf("version must be an integer, but %v", n)
+		}
+		version = int(n)
+	case json.Number:
+		i, err := n.Int64()
+		if err != nil {
+			return 0, errors.Errorf("version must be an integer, but %s", n)
+		}
+		version = int(i)
+	default:
+		return 0, errors.Errorf("version must be an integer, but %v", v)
+	}
+	if version < 1 {
+		return 0, errors.Errorf("version must be 1 or more, but %d", version)
+	}
+	return version, nil
+}
+
+// jsonErrorMessage returns the message of the json error with the line and the column of the error
+func jsonErrorMessage(b []byte, err error) string {
+	var offset int64
+	message := strings.TrimPrefix(err.Error(), "json: ")
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *json.UnmarshalTypeError:
+		offset = e.Offset
+		// the path of the keys instead of the go struct field
+		message = fmt.Sprintf("%s must be %s, but %s", jsonFieldPath(e.Field), jsonTypeName(e.Type), e.Value)
+	default:
+		return message
+	}
+	if offset > int64(len(b)) {
+		offset = int64(len(b))
+	}
+	line := bytes.Count(b[:offset], []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(b[:offset], '\n')
+	return fmt.Sprintf("%s (line %d, column %d)", message, line, column)
+}
+
+// jsonFieldPath returns the path of the field of json error like columns[0].columnName, the index is not in the field of older go
+func jsonFieldPath(field string) string {
+	var path string
+	for _, key := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(key); err == nil {
+			path += "[" + key + "]"
+		} else if path == "" {
+			path = key
+		} else {
+			path += "." + key
+		}
+	}
+	return path
+}
+
+// jsonTypeName returns the type of json for the go type
+func jsonTypeName(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return jsonTypeName(typ.Elem()) + " or null"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer of 0 or more"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,192 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTableJSONV1 = `{
+  "catalog": "def",
+  "schema": "appdb",
+  "name": "users",
+  "columns": [
+    {"tableName": "users", "columnName": "id", "dataType": "bigint", "columnType": "bigint(20)", "columnDefault": null}
+  ],
+  "indexes": [
+    {"TableName": "users", "IndexName": "PRIMARY", "ColumnName": "id", "SeqInIndex": 1}
+  ]
+}`
+
+func TestTable_UpgradeTableJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	problems := ValidateTableJSON([]byte(testTableJSONV1))
+	if assert.Len(problems, 1) {
+		assert.Contains(problems[0], "run `gendao upgrade-json`")
+	}
+
+	b, version, err := UpgradeTableJSON([]byte(testTableJSONV1))
+	assert.NoError(err)
+	assert.Equal(1, version)
+	assert.Empty(ValidateTableJSON(b))
+	assert.True(strings.HasPrefix(string(b), "{\n  \"version\": 2,\n  \"catalog\": \"def\","), string(b))
+	assert.Contains(string(b), `"foreignKeys": []`)
+
+	// the latest is not changed
+	res, version, err := UpgradeTableJSON(b)
+	assert.NoError(err)
+	assert.Equal(TableJSONVersion, version)
+	assert.Equal(string(b), string(res))
+
+	_, _, err = UpgradeTableJSON([]byte(`{"version": 99}`))
+	assert.EqualError(err, "version 99 is newer than 2, update gendao to read it")
+}
+
+func TestTable_ValidateTableJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		json     string
+		problems []string
+	}{
+		{`{"version": 2,`, []string{"unexpected end of JSON input (line 1, column 15)"}},
+		{`{"version": "2"}`, []string{"version must be an integer, but 2"}},
+		{"{\n  \"version\": 2,\n  \"name\": 1\n}", []string{"name must be string, but number (line 3, column 12)"}},
+		{`{"version": 2, "name": "users", "indexes": [{"NonUnique": -1}]}`, []string{"indexes[0].NonUnique must be integer of 0 or more, but number -1 (line 1, column 61)"}},
+		{`{"version": 2, "name": "users", "columns": [], "unknown": 1}`, []string{`unknown field "unknown"`}},
+		{`{"version": 2, "name": "users", "columns": []}`, []string{"columns must not be empty"}},
+		{`{"version": 2, "columns": [{"tableName": "users", "columnName": "id", "dataType": ""}], "indexes": [{"IndexName": "PRIMARY", "ColumnName": "uid"}], "foreignKeys": [{"constraintName": "fk", "columnName": "id"}]}`, []string{
+			"name is required",
+			"columns[0].columnType is required",
+			"columns[0].dataType must not be empty",
+			"foreignKeys[0].referencedTableName is required",
+			"foreignKeys[0].referencedColumnName is required",
+			`columns[0].tableName "users" is not the name ""`,
+			`indexes[0].ColumnName "uid" is not in the columns`,
+		}},
+	}
+	for _, tt := range tests {
+		assert.Equal(tt.problems, ValidateTableJSON([]byte(tt.json)), tt.json)
+	}
+}
+
+// TestTable_schema checks the properties of schema/table.schema.json are the fields of the json
+func TestTable_schema(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := ioutil.ReadFile("../../schema/table.schema.json")
+	if !assert.NoError(err) {
+		return
+	}
+	type properties map[string]json.RawMessage
+	var schema struct {
+		Properties properties `json:"properties"`
+		Defs       map[string]struct {
+			Properties properties `json:"properties"`
+		} `json:"$defs"`
+	}
+	assert.NoError(json.Unmarshal(b, &schema))
+	assert.JSONEq(fmt.Sprintf(`{"const": %d}`, TableJSONVersion), string(schema.Properties["version"]))
+
+	names := func(v interface{}) []string {
+		var res []string
+		typ := reflect.TypeOf(v)
+		for i := 0; i < typ.NumField(); i++ {
+			name := typ.Field(i).Name
+			if tag := typ.Field(i).Tag.Get("json"); tag != "" {
+				name = tag
+			}
+			res = append(res, name)
+		}
+		sort.Strings(res)
+		return res
+	}
+	keys := func(m properties) []string {
+		var res []string
+		for key := range m {
+			res = append(res, key)
+		}
+		sort.Strings(res)
+		return res
+	}
+	assert.Equal(names(Table{}), keys(schema.Properties))
+	assert.Equal(names(Column{}), keys(schema.Defs["column"].Properties))
+	assert.Equal(names(Index{}), keys(schema.Defs["index"].Properties))
+	assert.Equal(names(ForeignKey{}), keys(schema.Defs["foreignKey"].Properties))
+}
+
+// TestTable_schemaRules checks the rules of ValidateTableJSON are same as schema/table.schema.json
+func TestTable_schemaRules(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := ioutil.ReadFile("../../schema/table.schema.json")
+	if !assert.NoError(err) {
+		return
+	}
+	type property struct {
+		MinLength int `json:"minLength"`
+		MinItems  int `json:"minItems"`
+		Items     struct {
+			Ref string `json:"$ref"`
+		} `json:"items"`
+	}
+	type object struct {
+		Properties map[string]property `json:"properties"`
+		Required   []string            `json:"required"`
+	}
+	var schema struct {
+		object
+		Defs map[string]object `json:"$defs"`
+	}
+	assert.NoError(json.Unmarshal(b, &schema))
+	objects := map[string]object{"table": schema.object}
+	for name, def := range schema.Defs {
+		objects[name] = def
+	}
+
+	assert.Len(tableJSONRules, len(objects))
+	for name, obj := range objects {
+		rule, ok := tableJSONRules[name]
+		if !assert.True(ok, name) {
+			continue
+		}
+		var nonEmpty []string
+		for key, p := range obj.Properties {
+			if p.MinLength > 0 || p.MinItems > 0 {
+				nonEmpty = append(nonEmpty, key)
+			}
+		}
+		assert.ElementsMatch(obj.Required, rule.required, name)
+		assert.ElementsMatch(nonEmpty, rule.nonEmpty, name)
+	}
+	for _, array := range tableJSONArrays {
+		assert.Equal("#/$defs/"+array.def, schema.Properties[array.key].Items.Ref, array.key)
+	}
+
+	// the keys in the problems are the keys of the schema
+	problems := ValidateTableJSON([]byte(`{"version": 2, "name": "users",
+		"columns": [{"tableName": "items", "columnName": "id", "dataType": "int", "columnType": "int"}, {"tableName": "users", "columnName": "id", "dataType": "int", "columnType": "int"}],
+		"indexes": [{"IndexName": "PRIMARY", "ColumnName": "uid"}],
+		"foreignKeys": [{"constraintName": "fk", "columnName": "uid", "referencedTableName": "items", "referencedColumnName": "id"}]}`))
+	assert.Len(problems, 4)
+	keyReg := regexp.MustCompile(`^(\w+)\[\d+\]\.(\w+) `)
+	for _, problem := range problems {
+		match := keyReg.FindStringSubmatch(problem)
+		if !assert.NotNil(match, problem) {
+			continue
+		}
+		for _, array := range tableJSONArrays {
+			if array.key == match[1] {
+				assert.Contains(objects[array.def].Properties, match[2], problem)
+			}
+		}
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"regexp"
+	"time"
 
 	"gopkg.in/urfave/cli.v1"
 
@@ -41,11 +43,21 @@ func main() {
 
 	passwordFlag := cli.StringFlag{
 		Name:  "password",
-		Usage: "Password to connect to mysql",
+		Usage: "Password to connect to mysql, which must be a reference to the environment variable like '${DB_PASSWORD}'",
 	}
 	pFlag := passwordFlag
 	pFlag.Name = "p"
 
+	passwordEnvFlag := cli.StringFlag{
+		Name:  "password-env",
+		Usage: "Environment variable of password to connect to mysql",
+	}
+
+	passwordFileFlag := cli.StringFlag{
+		Name:  "password-file",
+		Usage: "File of password to connect to mysql",
+	}
+
 	databaseFlag := cli.StringFlag{
 		Name:  "database",
 		Usage: "target database name",
@@ -60,6 +72,26 @@ func main() {
 	tFlag := databaseFlag
 	tFlag.Name = "t"
 
+	rowsFlag := cli.IntFlag{
+		Name:  "rows",
+		Usage: "number of rows to insert into each table",
+		Value: 100,
+	}
+	nFlag := rowsFlag
+	nFlag.Name = "n"
+
+	seedFlag := cli.Int64Flag{
+		Name:  "seed",
+		Usage: "random seed to reproduce the rows (default: current time)",
+	}
+
+	outputFlag := cli.StringFlag{
+		Name:  "output",
+		Usage: "output directory of the documents (default: doc in outputSourcePath)",
+	}
+	oFlag := outputFlag
+	oFlag.Name = "o"
+
 	app := cli.NewApp()
 	app.Name = "gendao"
 	app.Usage = "make an dao and model source code for golang"
@@ -73,6 +105,7 @@ func main() {
 			Flags: []cli.Flag{
 				HFlag, PFlag, uFlag, pFlag, dFlag,
 				hostFlag, portFlag, userFlag, passwordFlag, databaseFlag,
+				passwordEnvFlag, passwordFileFlag,
 			},
 		},
 		{
@@ -95,6 +128,34 @@ func main() {
 			Action:    genAction,
 			Flags:     []cli.Flag{dFlag, tFlag, databaseFlag, tableFlag},
 		},
+		{
+			Name:      "seed",
+			Usage:     "Insert dummy rows into database from JSON",
+			ArgsUsage: "{config file path}",
+			Action:    seedAction,
+			Flags:     []cli.Flag{dFlag, tFlag, nFlag, databaseFlag, tableFlag, rowsFlag, seedFlag},
+		},
+		{
+			Name:      "upgrade-json",
+			Usage:     "Migrate tables JSON of older version to current version",
+			ArgsUsage: "{config file path}",
+			Action:    upgradeJSONAction,
+			Flags:     []cli.Flag{dFlag, databaseFlag},
+		},
+		{
+			Name:      "validate",
+			Usage:     "Check config against templates and tables JSON",
+			ArgsUsage: "{config file path}",
+			Action:    validateAction,
+			Flags:     []cli.Flag{dFlag, databaseFlag},
+		},
+		{
+			Name:      "doc",
+			Usage:     "Generate Markdown documents of tables from JSON",
+			ArgsUsage: "{config file path}",
+			Action:    docAction,
+			Flags:     []cli.Flag{dFlag, oFlag, databaseFlag, outputFlag},
+		},
 	}
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
@@ -116,7 +177,14 @@ func initAction(c *cli.Context) error {
 	user := getFlag(c, "user", "u")
 	password := getFlag(c, "password", "p")
 	dbname := getFlag(c, "database", "d")
-	b, err := dependency.NewConfig(host, port, user, password, dbname).ExportJSON()
+	// the config is committed, so the password is not written in plaintext
+	if password != "" && !envRefReg.MatchString(password) {
+		return errors.New("the password can not be written in plaintext, use a reference like '${DB_PASSWORD}', --password-env or --password-file instead")
+	}
+	conf := dependency.NewConfig(host, port, user, password, dbname)
+	conf.MysqlConfig.PasswordEnv = c.String("password-env")
+	conf.MysqlConfig.PasswordFile = c.String("password-file")
+	b, err := conf.ExportJSON()
 	if err != nil {
 		return err
 	}
@@ -138,6 +206,8 @@ func pullAction(c *cli.Context) error {
 	return nil
 }
 
+var envRefReg = regexp.MustCompile(`^\$\{\w+\}$`)
+
 var keyReg = regexp.MustCompile(`^\\w+\\.{1}\\w+$`)
 
 func addTypeAction(c *cli.Context) error {
@@ -222,8 +292,8 @@ Press ^C at any time to quit.`)
 	}
 
 	// update config
-	cmd.Config.CustomColumnType[key] = &data
-	if err := cmd.Config.Write(path); err != nil {
+	cmd.RawConfig.CustomColumnType[key] = &data
+	if err := cmd.RawConfig.Write(path); err != nil {
 		return err
 	}
 
@@ -254,6 +324,79 @@ func genAction(c *cli.Context) error {
 	return nil
 }
 
+func seedAction(c *cli.Context) error {
+	path := c.Args().First()
+	dbname := getFlag(c, "database", "d")
+	cmd, err := getConfig(path, dbname)
+	if err != nil {
+		return err
+	}
+	table := getFlag(c, "table", "t")
+	rows := c.Int("rows")
+	if c.IsSet("n") {
+		rows = c.Int("n")
+	}
+	randSeed := c.Int64("seed")
+	if !c.IsSet("seed") {
+		randSeed = time.Now().UnixNano()
+	}
+	if err := cmd.Seed(table, rows, randSeed); err != nil {
+		return err
+	}
+	fmt.Println("ok.")
+	return nil
+}
+
+func upgradeJSONAction(c *cli.Context) error {
+	path := c.Args().First()
+	dbname := getFlag(c, "database", "d")
+	cmd, err := getConfig(path, dbname)
+	if err != nil {
+		return err
+	}
+	if err := cmd.UpgradeJSON(); err != nil {
+		return err
+	}
+	fmt.Println("ok.")
+	return nil
+}
+
+func validateAction(c *cli.Context) error {
+	path := c.Args().First()
+	dbname := getFlag(c, "database", "d")
+	cmd, err := getConfig(path, dbname)
+	if err != nil {
+		return err
+	}
+	problems := cmd.Validate()
+	for _, p := range problems {
+		fmt.Println(" -", p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problems found", len(problems))
+	}
+	fmt.Println("ok.")
+	return nil
+}
+
+func docAction(c *cli.Context) error {
+	path := c.Args().First()
+	dbname := getFlag(c, "database", "d")
+	cmd, err := getConfig(path, dbname)
+	if err != nil {
+		return err
+	}
+	output := getFlag(c, "output", "o")
+	if output == "" {
+		output = filepath.Join(cmd.Config.OutputSourcePath, "doc")
+	}
+	if err := cmd.GenerateDoc(output); err != nil {
+		return err
+	}
+	fmt.Println("ok.")
+	return nil
+}
+
 func getConfig(path, dbName string) (*commands.Command, error) {
 	if path == "" {
 		fmt.Println("Please set the config.json created with the \"init\" command")
@@ -274,7 +417,7 @@ func getFormatTargetPaths(cmd *commands.Command) []string {
 	paths := make([]string,0, count)
 	uniqMap := make(map[string]bool, count)
 	appendPath := func(path string) {
-		if path == "" {
+		if !strings.HasSuffix(path, ".go") { // e.g. proto
 			return
 		}
 		ss := strings.Split(path, "/")
@@ -2,9 +2,12 @@ package scaffold
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"math"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -30,6 +33,7 @@ type (
 	TemplateData struct {
 		Config        dependency.Config
 		Table         TemplateDataTable
+		Tables        []TemplateDataTable
 		CommonColumns []TemplateDataColumn
 	}
 	// TemplateDataTable ...
@@ -46,6 +50,10 @@ type (
 		CustomMethods         []CustomMethod
 		CustomMethodUseTypes  []string
 		CustomMethodUseRanger bool
+		CustomMethodUseTime   bool
+		CustomMethodUseNull   bool
+		UpsertColumns         []TemplateDataColumn
+		ForeignKeys           []TemplateDataForeignKey
 	}
 	// TemplateDataColumn ...
 	TemplateDataColumn struct {
@@ -57,36 +65,58 @@ type (
 		Common           bool
 		AutoIncrement    bool
 		Unique           bool
+		UpsertIgnore     bool
+		Version          bool
+		SoftDelete       bool
 		SampleValue      string
+		// JSONName is the name in JSON of the model by jsonNaming of config, the field name by default
+		JSONName string
 		mysql.Column
 	}
 	// TemplateDataIndex ...
 	TemplateDataIndex struct {
-		Name          string
-		Columns       []TemplateDataColumn
-		Primary       bool
+		Name                    string
+		ColumnsNameByPascalcase string
+		Columns                 []TemplateDataColumn
+		Primary                 bool
+		Unique                  bool
+		UpsertColumns           []TemplateDataColumn
+		// AutoIncrement is true if the index is the single auto increment column, which is the auto increment key of gorp
 		AutoIncrement bool
-		Unique        bool
+		// HasAutoIncrement is true if any column of the index is auto increment, e.g. seq of (user_id, seq)
+		HasAutoIncrement bool
+	}
+	// TemplateDataForeignKey is a foreign key constraint, which may have multiple columns
+	TemplateDataForeignKey struct {
+		Name              string
+		Columns           []TemplateDataColumn
+		ReferencedTable   string
+		ReferencedColumns []string
+		// ReferencedNameByPascalcase is the model name of the referenced table
+		ReferencedNameByPascalcase string
 	}
 )
 
+// funcMap is the functions which can be called in the templates
+var funcMap = template.FuncMap{
+	"title": strings.Title,
+	"now": func() string {
+		return time.Now().Format(time.RFC3339)
+	},
+	"contains": helper.StringsContains,
+	"inc": func(i int) int {
+		return i + 1
+	},
+	"toJSON": toJSON,
+}
+
 func NewTemplate(inputPath string, tmplFiles []dependency.TemplateFile, outputPath string) (*MyTemplate, error) {
-	funcMap := template.FuncMap{
-		"title": strings.Title,
-		"now": func() string {
-			return time.Now().Format(time.RFC3339)
-		},
-		"contains": helper.StringsContains,
-	}
-	// load template
-	files := make([]string, len(tmplFiles))
+	tmpl, err := ParseTemplates(inputPath, tmplFiles)
+	if err != nil {
+		return nil, err
+	}
 	configs := make([]TemplateExportConfig, 0, len(tmplFiles))
-	for i, target := range tmplFiles {
-		files[i] = filepath.Join(inputPath, target.Name)
-		// check exists template file
-		if !helper.IsFileExist(files[i]) {
-			return nil, errors.Errorf("not found template file, [%s]", files[i])
-		}
+	for _, target := range tmplFiles {
 		// check output dir
 		path := filepath.Join(outputPath, target.ExportName)
 		dirPath := filepath.Dir(path)
@@ -104,12 +134,28 @@ func NewTemplate(inputPath string, tmplFiles []dependency.TemplateFile, outputPa
 		})
 	}
 	tp := MyTemplate{
-		Template:      template.Must(template.New("default").Funcs(funcMap).ParseFiles(files...)),
+		Template:      tmpl,
 		ExportConfigs: configs,
 	}
 	return &tp, nil
 }
 
+// ParseTemplates parses the template files in inputPath
+func ParseTemplates(inputPath string, tmplFiles []dependency.TemplateFile) (*template.Template, error) {
+	files := make([]string, len(tmplFiles))
+	for i, target := range tmplFiles {
+		files[i] = filepath.Join(inputPath, target.Name)
+		// check exists template file
+		if !helper.IsFileExist(files[i]) {
+			return nil, errors.Errorf("not found template file, [%s]", files[i])
+		}
+	}
+	if len(files) == 0 {
+		return template.New("default").Funcs(funcMap), nil
+	}
+	return template.New("default").Funcs(funcMap).ParseFiles(files...)
+}
+
 func (my MyTemplate) OutputSourceFileTable(data TemplateData) error {
 	for _, config := range my.ExportConfigs {
 		tmpl := my.Template.Lookup(config.TemplateName)
@@ -117,8 +163,7 @@ func (my MyTemplate) OutputSourceFileTable(data TemplateData) error {
 		if err := tmpl.Execute(buff, data); err != nil {
 			return err
 		}
-		name := helper.NewWordConverter(data.Table.Name).Singularize().ToString()
-		path := strings.Replace(config.ExportPathName, "{name}", name, -1)
+		path := strings.Replace(config.ExportPathName, "{name}", data.Table.FileName(), -1)
 		var res int
 		var err error
 		if config.Overwrite {
@@ -138,9 +183,24 @@ func (my MyTemplate) OutputSourceFileTable(data TemplateData) error {
 	return nil
 }
 
+// toJSON returns the indented JSON of v without HTML escape
+func toJSON(v interface{}) (string, error) {
+	buff := bytes.NewBuffer([]byte{})
+	enc := json.NewEncoder(buff)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return buff.String(), nil
+}
+
 var stdlibReg = regexp.MustCompile("^[a-z0-9/]+$")
 
-func NewTamplateParamTable(packageRoot string, table mysql.Table, commonColumns []string, customTypeMap map[string]*dependency.CustomColumnType) TemplateDataTable {
+// dummyPackage is the package called by the dummy constructor of the models
+const dummyPackage = "github.com/suzujun/gendao/dummy"
+
+func NewTamplateParamTable(config dependency.Config, table mysql.Table) TemplateDataTable {
 	pTable := TemplateDataTable{}
 	if len(table.Columns) == 0 {
 		return pTable
@@ -156,8 +216,16 @@ func NewTamplateParamTable(packageRoot string, table mysql.Table, commonColumns
 	typeMap := map[string]bool{}
 	for _, column := range table.Columns {
 		name := fmt.Sprintf("%s.%s", pTable.Name, column.ColumnName)
-		customType := customTypeMap[name]
-		tpColumn := newTemplateParamColumn(column, commonColumns, customType)
+		customType := config.CustomColumnType[name]
+		tpColumn := newTemplateParamColumn(column, config.CommonColumns, customType)
+		tpColumn.UpsertIgnore = helper.StringsContains(config.UpsertIgnoreColumns, column.ColumnName)
+		tpColumn.setJSONName(config.JSONNaming)
+		if column.ColumnName == config.GetVersionColumn(column.TableName) {
+			tpColumn.setVersion()
+		}
+		if column.ColumnName == config.SoftDeleteColumn && column.IsNullable {
+			tpColumn.setSoftDelete()
+		}
 		pTable.Columns = append(pTable.Columns, tpColumn)
 		names = append(names, column.ColumnName)
 		typeMap[tpColumn.Type] = true
@@ -168,7 +236,11 @@ func NewTamplateParamTable(packageRoot string, table mysql.Table, commonColumns
 		if pkg := tpColumn.getUsePackage(); pkg != "" {
 			packageMap[pkg] = ""
 		}
+		if tpColumn.useDummyPackage() {
+			packageMap[dummyPackage] = ""
+		}
 	}
+	pTable.ForeignKeys = newTemplateParamForeignKeys(table.ForeignKeys, pTable.Columns)
 	// get index info
 	indexes := newTemplateParamIndex(table.Indexes, pTable.Columns)
 	methods := make([]CustomMethod, 0, len(indexes))
@@ -179,7 +251,23 @@ func NewTamplateParamTable(packageRoot string, table mysql.Table, commonColumns
 		} else {
 			pTable.Indexes = append(pTable.Indexes, index)
 		}
-		methods = append(methods, GenCustomMethods(index, pTable.NameByPascalcase)...)
+		for _, m := range GenCustomMethods(index, pTable.NameByPascalcase) {
+			m.IndexName = index.Name
+			methods = append(methods, m)
+		}
+	}
+	for _, index := range indexes {
+		for _, m := range GenCursorMethods(index, pTable.PrimaryKey, pTable.NameByPascalcase) {
+			m.IndexName = index.Name
+			methods = append(methods, m)
+		}
+	}
+	// set upsert columns
+	pTable.UpsertColumns = pTable.upsertColumns(nil)
+	for i, index := range pTable.Indexes {
+		if index.Unique {
+			pTable.Indexes[i].UpsertColumns = pTable.upsertColumns(index.Columns)
+		}
 	}
 	// deduplication
 	methodMap := map[string]bool{}
@@ -191,6 +279,17 @@ func NewTamplateParamTable(packageRoot string, table mysql.Table, commonColumns
 		methodMap[m.Name] = true
 		pTable.CustomMethods = append(pTable.CustomMethods, m)
 	}
+	// add the variants which do not filter out the soft deleted rows
+	if pTable.SoftDeleteColumn() != nil {
+		methods := make([]CustomMethod, 0, len(pTable.CustomMethods)*2)
+		for _, m := range pTable.CustomMethods {
+			methods = append(methods, m)
+			m.Name += "IncludingDeleted"
+			m.IncludingDeleted = true
+			methods = append(methods, m)
+		}
+		pTable.CustomMethods = methods
+	}
 	// set using type for method params
 	uniquer := helper.NewUniquer()
 	for _, m := range methods {
@@ -199,6 +298,17 @@ func NewTamplateParamTable(packageRoot string, table mysql.Table, commonColumns
 			if strings.Contains(p.Type, "ranger.") {
 				pTable.CustomMethodUseRanger = true
 			}
+			if strings.Contains(p.Type, "time.Time") {
+				pTable.CustomMethodUseTime = true
+			}
+			if strings.Contains(p.Type, "null.") {
+				pTable.CustomMethodUseNull = true
+			}
+		}
+		for _, p := range m.Cursors {
+			if strings.Contains(p.Type, "time.Time") {
+				pTable.CustomMethodUseTime = true
+			}
 		}
 	}
 	pTable.CustomMethodUseTypes = uniquer.Uniq()
@@ -216,7 +326,7 @@ func NewTamplateParamTable(packageRoot string, table mysql.Table, commonColumns
 			v := fmt.Sprintf("%s \"%s\"", alias, pkg)
 			if stdlibReg.MatchString(pkg) { // standard library
 				pTable.UsePackages[0] = append(pTable.UsePackages[0], v)
-			} else if m, _ := regexp.MatchString("^"+packageRoot, pkg); m { // my package
+			} else if m, _ := regexp.MatchString("^"+config.PackageRoot, pkg); m { // my package
 				pTable.UsePackages[2] = append(pTable.UsePackages[2], v)
 			} else {
 				pTable.UsePackages[1] = append(pTable.UsePackages[1], v) // other library
@@ -248,6 +358,32 @@ func newTemplateParamColumn(column mysql.Column, commonColumns []string, customT
 	return tpc
 }
 
+func newTemplateParamForeignKeys(fks []mysql.ForeignKey, pColumns []TemplateDataColumn) []TemplateDataForeignKey {
+	pFKs := []TemplateDataForeignKey{}
+	columnMap := make(map[string]*TemplateDataColumn, len(pColumns))
+	for i, pColumn := range pColumns {
+		columnMap[pColumn.Name] = &pColumns[i]
+	}
+	indexMap := map[string]int{}
+	for _, fk := range fks {
+		i, ok := indexMap[fk.ConstraintName]
+		if !ok {
+			i = len(pFKs)
+			indexMap[fk.ConstraintName] = i
+			pFKs = append(pFKs, TemplateDataForeignKey{
+				Name:                       fk.ConstraintName,
+				ReferencedTable:            fk.ReferencedTableName,
+				ReferencedNameByPascalcase: helper.NewWordConverter(fk.ReferencedTableName).Pascalcase().Singularize().ToString(),
+			})
+		}
+		if pColumn := columnMap[fk.ColumnName]; pColumn != nil {
+			pFKs[i].Columns = append(pFKs[i].Columns, *pColumn)
+			pFKs[i].ReferencedColumns = append(pFKs[i].ReferencedColumns, fk.ReferencedColumnName)
+		}
+	}
+	return pFKs
+}
+
 func newTemplateParamIndex(indexes []mysql.Index, pColumns []TemplateDataColumn) []TemplateDataIndex {
 	pIndexes := []TemplateDataIndex{}
 	if len(indexes) == 0 || len(pColumns) == 0 {
@@ -275,10 +411,18 @@ func newTemplateParamIndex(indexes []mysql.Index, pColumns []TemplateDataColumn)
 	}
 	pIndexes = append(pIndexes, *pIndex)
 	for i, pIndex := range pIndexes {
+		names := make([]string, len(pIndex.Columns))
+		for j, column := range pIndex.Columns {
+			names[j] = column.NameByPascalcase
+		}
+		pIndexes[i].ColumnsNameByPascalcase = strings.Join(names, "And")
 		for _, column := range pIndex.Columns {
-			pIndexes[i].AutoIncrement = column.AutoIncrement
-			break
+			if column.AutoIncrement {
+				pIndexes[i].HasAutoIncrement = true
+			}
 		}
+		// gorp accepts only one column for the auto increment key
+		pIndexes[i].AutoIncrement = pIndexes[i].HasAutoIncrement && len(pIndex.Columns) == 1
 	}
 	return pIndexes
 }
@@ -293,6 +437,138 @@ func (tdt *TemplateDataTable) CommonColumns() []TemplateDataColumn {
 	return res
 }
 
+// preUpdateColumnName is the common column refreshed by preUpdate of the model, see model.tpl
+const preUpdateColumnName = "updated_at"
+
+// FileName returns the name of the table to replace {name} of exportName
+func (tdt TemplateDataTable) FileName() string {
+	return helper.NewWordConverter(tdt.Name).Singularize().ToString()
+}
+
+// InsertColumns returns the columns to be set on insert, except the auto increment primary key
+func (tdt TemplateDataTable) InsertColumns() []TemplateDataColumn {
+	res := make([]TemplateDataColumn, 0, len(tdt.Columns))
+	for _, column := range tdt.Columns {
+		if column.Primary && column.AutoIncrement && tdt.PrimaryKey.HasAutoIncrement {
+			continue
+		}
+		res = append(res, column)
+	}
+	return res
+}
+
+// PreUpdateColumns returns the common columns which are refreshed by preUpdate of the model on update
+func (tdt TemplateDataTable) PreUpdateColumns() []TemplateDataColumn {
+	res := []TemplateDataColumn{}
+	for _, column := range tdt.Columns {
+		if column.Common && column.Name == preUpdateColumnName {
+			res = append(res, column)
+		}
+	}
+	return res
+}
+
+// UniqueIndexes returns the unique indexes except the primary key
+func (tdt TemplateDataTable) UniqueIndexes() []TemplateDataIndex {
+	res := []TemplateDataIndex{}
+	for _, index := range tdt.Indexes {
+		if index.Unique {
+			res = append(res, index)
+		}
+	}
+	return res
+}
+
+// AutoIncrementColumn returns the auto increment column, or nil if the table has none
+func (tdt TemplateDataTable) AutoIncrementColumn() *TemplateDataColumn {
+	for _, column := range tdt.Columns {
+		if column.AutoIncrement {
+			return &column
+		}
+	}
+	return nil
+}
+
+// VersionColumn returns the version column for optimistic locking, or nil if the table has none
+func (tdt TemplateDataTable) VersionColumn() *TemplateDataColumn {
+	for _, column := range tdt.Columns {
+		if column.Version {
+			return &column
+		}
+	}
+	return nil
+}
+
+// SoftDeleteColumn returns the soft delete column, or nil if the table has none
+func (tdt TemplateDataTable) SoftDeleteColumn() *TemplateDataColumn {
+	for _, column := range tdt.Columns {
+		if column.SoftDelete {
+			return &column
+		}
+	}
+	return nil
+}
+
+// upsertColumns returns the columns to be updated when the key is duplicated
+func (tdt *TemplateDataTable) upsertColumns(excludes []TemplateDataColumn) []TemplateDataColumn {
+	res := []TemplateDataColumn{}
+	for _, column := range tdt.Columns {
+		if column.Primary || column.UpsertIgnore || column.Version {
+			continue
+		}
+		excluded := false
+		for _, exclude := range excludes {
+			if exclude.Name == column.Name {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			res = append(res, column)
+		}
+	}
+	return res
+}
+
+// setVersion marks the column as the version column.
+// The type is changed to signed integer because gorp handles the version by reflect.Value.Int.
+func (tdc *TemplateDataColumn) setVersion() {
+	tdc.Version = true
+	if strings.HasPrefix(tdc.Type, "uint") {
+		tdc.Type = strings.TrimPrefix(tdc.Type, "u")
+	}
+	tdc.SampleValue = "1"
+}
+
+func (tdc *TemplateDataColumn) setJSONName(naming string) {
+	switch naming {
+	case dependency.JSONNamingSnake:
+		tdc.JSONName = tdc.Name
+	case dependency.JSONNamingCamel:
+		tdc.JSONName = tdc.NameByCamelcase
+	default:
+		tdc.JSONName = tdc.NameByPascalcase
+	}
+}
+
+// setSoftDelete marks the column as the soft delete column, the dummy model is not deleted.
+func (tdc *TemplateDataColumn) setSoftDelete() {
+	tdc.SoftDelete = true
+	if strings.HasPrefix(tdc.Type, "null.") {
+		tdc.SampleValue = tdc.Type + "{}"
+	}
+}
+
+// useDummyPackage returns true if the dummy constructor of the model calls the dummy package for the column
+func (tdc *TemplateDataColumn) useDummyPackage() bool {
+	if tdc.Common {
+		return false
+	} else if tdc.Primary {
+		return tdc.Type == "string"
+	}
+	return strings.Contains(tdc.SampleValue, "dummy.")
+}
+
 func (tdc *TemplateDataColumn) getUsePackage() string {
 	if tdc.Type == "time.Time" {
 		return "time"
@@ -369,63 +645,72 @@ func (tdc *TemplateDataColumn) setType() {
 	})(tdc.Column)
 }
 
+// setSampleValue sets the expression of the dummy value, which calls the dummy package with r *rand.Rand of the dummy constructor
 func (tdc *TemplateDataColumn) setSampleValue() {
 	tdc.SampleValue = (func(c *TemplateDataColumn) string {
-		if c.Type == "string" || c.Type == "null.String" {
-			max := int(*c.Column.CharacterMaximumLength)
-			min := max / 3
-			if c.Type == "null.String" {
-				return fmt.Sprintf("randNullStringRange(%d, %d)", min, max)
+		mc := c.Column
+		nullPrefix := ""
+		if strings.HasPrefix(c.Type, "null.") {
+			nullPrefix = "Null"
+		}
+		switch c.Type {
+		case "string", "null.String":
+			if values := mc.EnumValues(); len(values) > 0 {
+				quoted := make([]string, len(values))
+				for i, v := range values {
+					quoted[i] = strconv.Quote(v)
+				}
+				fnc := "Enum"
+				if mc.DataType == "set" {
+					fnc = "Set"
+				}
+				return fmt.Sprintf("dummy.%s%s(r, %s)", nullPrefix, fnc, strings.Join(quoted, ", "))
+			}
+			// the dummy string is ASCII, so the length is limited by both of the characters and the bytes
+			max := uintValue(mc.CharacterMaximumLength, 0)
+			if octet := uintValue(mc.CharacterOctetLength, max); octet < max {
+				max = octet
+			}
+			return fmt.Sprintf("dummy.%sString(r, %d, %d)", nullPrefix, max/3, max)
+		case "time.Time", "null.Time":
+			if mc.DataType == "date" {
+				return fmt.Sprintf("dummy.%sDate(r)", nullPrefix)
+			}
+			return fmt.Sprintf("dummy.%sTime(r, %d)", nullPrefix, uintValue(mc.DatetimePrecision, 0))
+		case "null.Int":
+			rng := mc.DataTypeRange()
+			max := rng.Max
+			if max > math.MaxInt64 {
+				max = math.MaxInt64 // null.Int is int64
 			}
-			return fmt.Sprintf("randStringRange(%d, %d)", min, max)
-		} else if c.Type == "time.Time" {
-			return "time.Unix(time.Now().Unix(), 0)"
-		} else if strings.HasPrefix(c.Type, "null.") {
-			switch c.Type {
-			case "null.Int":
-				r := c.Column.DataTypeRange()
-				return fmt.Sprintf("randNullInt(%d)", r.Max)
-			case "null.Float":
-				return "randNullFloat()"
-			case "null.Time":
-				return "randNullTime()"
+			return fmt.Sprintf("dummy.NullInt64(r, %d, %d)", rng.Min, max)
+		case "int8", "int16", "int32", "int64":
+			rng := mc.DataTypeRange()
+			return fmt.Sprintf("%s(dummy.Int64(r, %d, %d))", c.Type, rng.Min, rng.Max)
+		case "uint8", "uint16", "uint32", "uint64":
+			rng := mc.DataTypeRange()
+			return fmt.Sprintf("%s(dummy.Uint64(r, %d, %d))", c.Type, rng.Min, rng.Max)
+		case "float32", "float64", "null.Float":
+			min, decimal := -1000, "Decimal"
+			if mc.Unsigned() {
+				min, decimal = 0, "UnsignedDecimal"
 			}
-		} else if strings.Contains(c.Type, "int") {
-			switch c.Type {
-			case "int":
-				return "rand.Int()"
-			case "int64":
-				return "rand.Int63()"
-			case "int32":
-				return "rand.Int31()"
-			case "int16":
-				return "int16(rand.Intn(32767))"
-			case "int8":
-				return "int8(rand.Intn(127))"
-			case "uint":
-				return "uint(rand.Uint64())"
-			case "uint64":
-				// return "rand.Uint64()" // fail at mysql: uint64 values with high bit set are not supported
-				return "uint64(rand.Uint32())"
-			case "uint32":
-				return "uint32(rand.Intn(4294967295))"
-			case "uint16":
-				return "uint16(rand.Intn(65535))"
-			case "uint8":
-				return "uint8(rand.Intn(255))"
-			default:
-				return "1"
+			value := fmt.Sprintf("dummy.%sFloat64(r, %d, 1000)", nullPrefix, min)
+			if mc.DataType == "decimal" || mc.DataType == "dec" || mc.NumericScale != nil {
+				value = fmt.Sprintf("dummy.%s%s(r, %d, %d)", nullPrefix, decimal, uintValue(mc.NumericPrecision, 10), uintValue(mc.NumericScale, 0))
 			}
-		} else if strings.HasPrefix(c.Type, "float") {
-			switch c.Type {
-			case "float32":
-				return "rand.Float32()" // TODO mysql variable type に応じた値を設定する
-			case "float64":
-				return "rand.Float64()" // TODO mysql variable type に応じた値を設定する
-			default:
-				return "1.01"
+			if c.Type == "float32" {
+				return "float32(" + value + ")"
 			}
+			return value
 		}
 		return "" // TODO バイナリとかの対応
 	})(tdc)
 }
+
+func uintValue(p *uint, def int) int {
+	if p == nil {
+		return def
+	}
+	return int(*p)
+}
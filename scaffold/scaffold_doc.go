@@ -0,0 +1,220 @@
+package scaffold
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/suzujun/gendao/helper"
+)
+
+// DocRelation is a relation between the tables for the documents
+type DocRelation struct {
+	// Table is the referencing table, and ReferencedTable is the referenced table
+	Table             string
+	Columns           []string
+	ReferencedTable   string
+	ReferencedColumns []string
+	// Nullable is true if the referencing row may have no referenced row
+	Nullable bool
+	// Unique is true if the referenced row has at most one referencing row
+	Unique bool
+	// Inferred is true if the relation is not a foreign key but inferred by the column name, e.g. user_id is users.id
+	Inferred bool
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+// markdownCell escapes s to put in a cell of Markdown table
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
+
+// DocDefault returns the default value of the column, NULL if the nullable column has no default value
+func (tdc TemplateDataColumn) DocDefault() string {
+	switch v := tdc.Column.ColumnDefault.(type) {
+	case nil:
+		if tdc.IsNullable {
+			return "NULL"
+		}
+		return ""
+	case []byte:
+		return markdownCell(string(v))
+	default:
+		return markdownCell(fmt.Sprint(v))
+	}
+}
+
+// DocComment returns the comment of the column to put in a cell of Markdown table
+func (tdc TemplateDataColumn) DocComment() string {
+	return markdownCell(tdc.Column.ColumnComment)
+}
+
+// DocColumnsName returns the names of the columns of the index joined with comma
+func (tdi TemplateDataIndex) DocColumnsName() string {
+	names := make([]string, len(tdi.Columns))
+	for i, column := range tdi.Columns {
+		names[i] = column.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// DocRelations returns the relations which the table references.
+// The foreign keys are used, and the columns named like user_id are inferred as users.id if the table has no foreign keys.
+func (tdt TemplateDataTable) DocRelations(tables []TemplateDataTable) []DocRelation {
+	tableMap := make(map[string]TemplateDataTable, len(tables))
+	for _, table := range tables {
+		tableMap[table.Name] = table
+	}
+	res := []DocRelation{}
+	if len(tdt.ForeignKeys) > 0 {
+		for _, fk := range tdt.ForeignKeys {
+			if len(fk.Columns) == 0 {
+				continue
+			}
+			rel := DocRelation{Table: tdt.Name, ReferencedTable: fk.ReferencedTable, ReferencedColumns: fk.ReferencedColumns}
+			for _, column := range fk.Columns {
+				rel.Columns = append(rel.Columns, column.Name)
+				rel.Nullable = rel.Nullable || column.IsNullable
+			}
+			rel.Unique = tdt.uniqueColumns(rel.Columns)
+			res = append(res, rel)
+		}
+		return res
+	}
+	for _, column := range tdt.Columns {
+		if column.Primary || !strings.HasSuffix(column.Name, "_id") {
+			continue
+		}
+		name := helper.NewWordConverter(strings.TrimSuffix(column.Name, "_id")).Pluralize().ToString()
+		referenced, ok := tableMap[name]
+		if !ok || len(referenced.PrimaryKey.Columns) != 1 || referenced.PrimaryKey.Columns[0].Name != "id" {
+			continue
+		}
+		res = append(res, DocRelation{
+			Table:             tdt.Name,
+			Columns:           []string{column.Name},
+			ReferencedTable:   name,
+			ReferencedColumns: []string{"id"},
+			Nullable:          column.IsNullable,
+			Unique:            tdt.uniqueColumns([]string{column.Name}),
+			Inferred:          true,
+		})
+	}
+	return res
+}
+
+// uniqueColumns returns true if the primary key or an unique index consists of the columns
+func (tdt TemplateDataTable) uniqueColumns(names []string) bool {
+	indexes := append([]TemplateDataIndex{tdt.PrimaryKey}, tdt.Indexes...)
+	for _, index := range indexes {
+		if !index.Unique || len(index.Columns) != len(names) {
+			continue
+		}
+		found := true
+		for _, column := range index.Columns {
+			found = found && helper.StringsContains(names, column.Name)
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// DocReferencedBy returns the relations which reference the table
+func (tdt TemplateDataTable) DocReferencedBy(tables []TemplateDataTable) []DocRelation {
+	sorted := make([]TemplateDataTable, len(tables))
+	copy(sorted, tables)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	res := []DocRelation{}
+	for _, table := range sorted {
+		for _, rel := range table.DocRelations(tables) {
+			if rel.ReferencedTable == tdt.Name {
+				res = append(res, rel)
+			}
+		}
+	}
+	return res
+}
+
+// DocRelations returns the relations of all tables
+func (td TemplateData) DocRelations() []DocRelation {
+	res := []DocRelation{}
+	for _, table := range td.Tables {
+		res = append(res, table.DocRelations(td.Tables)...)
+	}
+	return res
+}
+
+// MermaidCardinality returns the relationship of Mermaid erDiagram from the referenced table, e.g. ||--o{
+func (rel DocRelation) MermaidCardinality() string {
+	left := "||"
+	if rel.Nullable {
+		left = "|o"
+	}
+	right := "o{"
+	if rel.Unique {
+		right = "o|"
+	}
+	line := "--"
+	if rel.Inferred {
+		line = ".." // non-identifying, since it is not constrained
+	}
+	return left + line + right
+}
+
+// MermaidLabel returns the label of the relationship of Mermaid erDiagram
+func (rel DocRelation) MermaidLabel() string {
+	return mermaidQuote(strings.Join(rel.Columns, ", "))
+}
+
+// FileName returns the name of the referencing table to replace {name} of exportName
+func (rel DocRelation) FileName() string {
+	return TemplateDataTable{Name: rel.Table}.FileName()
+}
+
+// ReferencedFileName returns the name of the referenced table to replace {name} of exportName
+func (rel DocRelation) ReferencedFileName() string {
+	return TemplateDataTable{Name: rel.ReferencedTable}.FileName()
+}
+
+// DocColumnsName returns the referencing columns joined with comma
+func (rel DocRelation) DocColumnsName() string {
+	return strings.Join(rel.Columns, ", ")
+}
+
+// DocReferencedColumnsName returns the referenced columns joined with comma
+func (rel DocRelation) DocReferencedColumnsName() string {
+	return strings.Join(rel.ReferencedColumns, ", ")
+}
+
+// MermaidAttribute returns the attribute of the entity of Mermaid erDiagram, e.g. bigint id PK "user id"
+func (tdc TemplateDataColumn) MermaidAttribute(relations []DocRelation) string {
+	var keys []string
+	if tdc.Primary {
+		keys = append(keys, "PK")
+	}
+	for _, rel := range relations {
+		if helper.StringsContains(rel.Columns, tdc.Name) {
+			keys = append(keys, "FK")
+			break
+		}
+	}
+	if tdc.Unique && !tdc.Primary {
+		keys = append(keys, "UK")
+	}
+	res := tdc.DataType + " " + tdc.Name
+	if len(keys) > 0 {
+		res += " " + strings.Join(keys, ", ")
+	}
+	if tdc.Column.ColumnComment != "" {
+		res += " " + mermaidQuote(tdc.Column.ColumnComment)
+	}
+	return res
+}
+
+// mermaidQuote returns the string of Mermaid, which cannot have double quotes
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "'", "\n", " ").Replace(s) + `"`
+}
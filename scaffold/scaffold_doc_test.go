@@ -0,0 +1,70 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suzujun/gendao/dependency"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+func TestScaffold_DocRelations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	users := NewTamplateParamTable(config, newTestMysqlTable())
+	postsTable := mysql.Table{
+		Name: "posts",
+		Columns: []mysql.Column{
+			{TableName: "posts", ColumnName: "id", DataType: "bigint", ColumnType: "bigint(20)", ColumnKey: "PRI"},
+			{TableName: "posts", ColumnName: "user_id", DataType: "bigint", ColumnType: "bigint(20) unsigned", IsNullable: true},
+			{TableName: "posts", ColumnName: "category_id", DataType: "bigint", ColumnType: "bigint(20) unsigned"},
+		},
+		Indexes: []mysql.Index{
+			{TableName: "posts", IndexName: "PRIMARY", ColumnName: "id", SeqInIndex: 1},
+		},
+	}
+
+	// inferred by the column names without the foreign keys
+	posts := NewTamplateParamTable(config, postsTable)
+	tables := []TemplateDataTable{users, posts}
+	rels := posts.DocRelations(tables)
+	if assert.Len(rels, 1) {
+		assert.Equal([]string{"user_id"}, rels[0].Columns)
+		assert.Equal("users", rels[0].ReferencedTable)
+		assert.True(rels[0].Inferred)
+		assert.Equal("|o..o{", rels[0].MermaidCardinality())
+		assert.Equal("user", rels[0].ReferencedFileName())
+	}
+	assert.Equal(rels, users.DocReferencedBy(tables))
+	assert.Equal("bigint user_id FK", posts.Columns[1].MermaidAttribute(rels))
+
+	// the foreign keys have priority over the column names
+	postsTable.ForeignKeys = []mysql.ForeignKey{
+		{ConstraintName: "fk_category", TableName: "posts", ColumnName: "category_id", ReferencedTableName: "categories", ReferencedColumnName: "id"},
+	}
+	posts = NewTamplateParamTable(config, postsTable)
+	rels = posts.DocRelations([]TemplateDataTable{users, posts})
+	if assert.Len(rels, 1) {
+		assert.Equal("categories", rels[0].ReferencedTable)
+		assert.False(rels[0].Inferred)
+		assert.Equal("||--o{", rels[0].MermaidCardinality())
+	}
+}
+
+func TestScaffold_DocDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		column mysql.Column
+		want   string
+	}{
+		{mysql.Column{ColumnDefault: nil}, ""},
+		{mysql.Column{ColumnDefault: nil, IsNullable: true}, "NULL"},
+		{mysql.Column{ColumnDefault: "a|b"}, `a\|b`},
+		{mysql.Column{ColumnDefault: float64(1)}, "1"},
+	}
+	for _, tt := range tests {
+		assert.Equal(tt.want, TemplateDataColumn{Column: tt.column}.DocDefault())
+	}
+}
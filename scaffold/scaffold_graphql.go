@@ -0,0 +1,180 @@
+package scaffold
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/suzujun/gendao/helper"
+)
+
+type (
+	// GraphQLField is a field of GraphQL type
+	GraphQLField struct {
+		Name string
+		Args []GraphQLArg
+		Type string
+	}
+	// GraphQLArg is an argument of GraphQL field
+	GraphQLArg struct {
+		Name string
+		Type string
+	}
+)
+
+// graphQLScalars are the custom scalars used by GraphQLType
+var graphQLScalars = []string{"Int64", "Uint64", "Time"}
+
+// GraphQLType returns the type of GraphQL, which is non-null unless the column is nullable.
+// The integers out of 32 bit are the custom scalars Int64 and Uint64, and the time is the custom scalar Time.
+func (tdc TemplateDataColumn) GraphQLType() string {
+	t := tdc.graphQLNamedType()
+	if tdc.IsNullable {
+		return t
+	}
+	return t + "!"
+}
+
+func (tdc TemplateDataColumn) graphQLNamedType() string {
+	switch tdc.Column.DataType {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint", "year", "bit":
+		rng := tdc.Column.DataTypeRange()
+		if rng.Min >= math.MinInt32 && rng.Max <= math.MaxInt32 {
+			return "Int"
+		} else if rng.Max <= math.MaxInt64 {
+			return "Int64"
+		}
+		return "Uint64"
+	case "float", "double", "real", "decimal", "dec", "numeric":
+		return "Float"
+	case "date", "datetime", "timestamp", "time":
+		return "Time"
+	default:
+		return "String"
+	}
+}
+
+// GraphQLDescription returns the description of the column in GraphQL block string, or empty if no comment
+func (tdc TemplateDataColumn) GraphQLDescription() string {
+	if tdc.Column.ColumnComment == "" {
+		return ""
+	}
+	return `"""` + strings.Replace(tdc.Column.ColumnComment, `"""`, `\"""`, -1) + `"""`
+}
+
+// GraphQLQueries returns the query fields of the finder methods by the unique indexes
+func (tdt TemplateDataTable) GraphQLQueries() []GraphQLField {
+	columnMap := make(map[string]TemplateDataColumn, len(tdt.Columns))
+	for _, column := range tdt.Columns {
+		columnMap[column.Name] = column
+	}
+	res := []GraphQLField{}
+	for _, m := range tdt.CustomMethods {
+		if !m.Unique || m.ReturnMany || m.Count || m.Exists || len(m.Cursors) > 0 || m.IncludingDeleted || m.RangeParam != nil {
+			continue
+		}
+		field := GraphQLField{
+			Name: tdt.NameByCamelcase + strings.TrimPrefix(m.Name, "Find"),
+			Type: tdt.NameByPascalcase,
+		}
+		for _, p := range m.Params {
+			column, ok := columnMap[p.Name]
+			if !ok || !p.Where {
+				field.Args = nil
+				break
+			}
+			t := column.graphQLNamedType() + "!" // NULL is never found by the finder
+			field.Args = append(field.Args, GraphQLArg{Name: p.NameByCamelcase, Type: t})
+		}
+		if len(field.Args) > 0 {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+// GraphQLRelations returns the fields of the referenced models by the foreign keys of the table,
+// and the fields of the referencing models by the foreign keys of the other tables
+func (tdt TemplateDataTable) GraphQLRelations(tables []TemplateDataTable) []GraphQLField {
+	tableMap := make(map[string]TemplateDataTable, len(tables))
+	for _, table := range tables {
+		tableMap[table.Name] = table
+	}
+	res := []GraphQLField{}
+	names := map[string]bool{}
+	for _, column := range tdt.Columns {
+		names[column.NameByCamelcase] = true
+	}
+	add := func(name, suffix, t string) {
+		if names[name] {
+			name += suffix
+		}
+		names[name] = true
+		res = append(res, GraphQLField{Name: name, Type: t})
+	}
+	for _, fk := range tdt.ForeignKeys {
+		if _, ok := tableMap[fk.ReferencedTable]; !ok || len(fk.Columns) == 0 {
+			continue
+		}
+		name := helper.NewWordConverter(fk.ReferencedTable).Camelcase().Singularize().ToString()
+		nullable := false
+		for _, column := range fk.Columns {
+			nullable = nullable || column.IsNullable
+		}
+		if len(fk.Columns) == 1 && strings.HasSuffix(fk.Columns[0].Name, "_id") {
+			name = helper.NewWordConverter(strings.TrimSuffix(fk.Columns[0].Name, "_id")).Camelcase().Lint().ToString()
+		}
+		t := fk.ReferencedNameByPascalcase
+		if !nullable {
+			t += "!"
+		}
+		add(name, "By"+fk.columnsNameByPascalcase(), t)
+	}
+	sorted := make([]TemplateDataTable, len(tables))
+	copy(sorted, tables)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, table := range sorted {
+		for _, fk := range table.ForeignKeys {
+			if fk.ReferencedTable != tdt.Name || len(fk.Columns) == 0 {
+				continue
+			}
+			name := helper.NewWordConverter(table.Name).Camelcase().Pluralize().ToString()
+			add(name, "By"+fk.columnsNameByPascalcase(), "["+table.NameByPascalcase+"!]!")
+		}
+	}
+	return res
+}
+
+// GraphQLQueries returns the query fields of all tables
+func (td TemplateData) GraphQLQueries() []GraphQLField {
+	res := []GraphQLField{}
+	for _, table := range td.Tables {
+		res = append(res, table.GraphQLQueries()...)
+	}
+	return res
+}
+
+// GraphQLScalars returns the custom scalars used by the tables
+func (td TemplateData) GraphQLScalars() []string {
+	used := map[string]bool{}
+	for _, table := range td.Tables {
+		for _, column := range table.Columns {
+			used[column.graphQLNamedType()] = true
+		}
+	}
+	res := []string{}
+	for _, scalar := range graphQLScalars {
+		if used[scalar] {
+			res = append(res, scalar)
+		}
+	}
+	return res
+}
+
+func (fk TemplateDataForeignKey) columnsNameByPascalcase() string {
+	names := make([]string, len(fk.Columns))
+	for i, column := range fk.Columns {
+		names[i] = column.NameByPascalcase
+	}
+	return strings.Join(names, "And")
+}
@@ -0,0 +1,79 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suzujun/gendao/dependency"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+func TestScaffold_GraphQLType(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		column   mysql.Column
+		expected string
+	}{
+		{mysql.Column{DataType: "int", ColumnType: "int(11)"}, "Int!"},
+		{mysql.Column{DataType: "int", ColumnType: "int(10) unsigned"}, "Int64!"},
+		{mysql.Column{DataType: "bigint", ColumnType: "bigint(20) unsigned", IsNullable: true}, "Uint64"},
+		{mysql.Column{DataType: "decimal", ColumnType: "decimal(10,2)"}, "Float!"},
+		{mysql.Column{DataType: "datetime", ColumnType: "datetime", IsNullable: true}, "Time"},
+		{mysql.Column{DataType: "enum", ColumnType: "enum('a','b')"}, "String!"},
+	}
+	for _, tt := range tests {
+		column := TemplateDataColumn{Column: tt.column}
+		assert.Equal(tt.expected, column.GraphQLType(), tt.column.ColumnType)
+	}
+	assert.Equal(`"""it's \"""quoted\""""""`, TemplateDataColumn{Column: mysql.Column{ColumnComment: `it's """quoted"""`}}.GraphQLDescription())
+}
+
+func TestScaffold_GraphQLQueries(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	table := NewTamplateParamTable(config, newTestMysqlTable())
+	queries := table.GraphQLQueries()
+	if assert.Len(queries, 2) {
+		assert.Equal("userByID", queries[0].Name)
+		assert.Equal([]GraphQLArg{{Name: "id", Type: "Uint64!"}}, queries[0].Args)
+		assert.Equal("User", queries[0].Type)
+		assert.Equal("userByEmail", queries[1].Name)
+	}
+}
+
+func TestScaffold_GraphQLRelations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	users := NewTamplateParamTable(config, newTestMysqlTable())
+	posts := NewTamplateParamTable(config, mysql.Table{
+		Name: "posts",
+		Columns: []mysql.Column{
+			{TableName: "posts", ColumnName: "id", DataType: "bigint", ColumnType: "bigint(20)", ColumnKey: "PRI"},
+			{TableName: "posts", ColumnName: "user_id", DataType: "bigint", ColumnType: "bigint(20) unsigned"},
+			{TableName: "posts", ColumnName: "editor_id", DataType: "bigint", ColumnType: "bigint(20) unsigned", IsNullable: true},
+		},
+		ForeignKeys: []mysql.ForeignKey{
+			{ConstraintName: "fk_user", TableName: "posts", ColumnName: "user_id", ReferencedTableName: "users", ReferencedColumnName: "id"},
+			{ConstraintName: "fk_editor", TableName: "posts", ColumnName: "editor_id", ReferencedTableName: "users", ReferencedColumnName: "id"},
+			{ConstraintName: "fk_unknown", TableName: "posts", ColumnName: "user_id", ReferencedTableName: "unknowns", ReferencedColumnName: "id"},
+		},
+	})
+	if assert.Len(posts.ForeignKeys, 3) {
+		assert.Equal("fk_user", posts.ForeignKeys[0].Name)
+		assert.Equal("user_id", posts.ForeignKeys[0].Columns[0].Name)
+		assert.Equal([]string{"id"}, posts.ForeignKeys[0].ReferencedColumns)
+		assert.Equal("User", posts.ForeignKeys[0].ReferencedNameByPascalcase)
+	}
+	tables := []TemplateDataTable{users, posts}
+	assert.Equal([]GraphQLField{
+		{Name: "user", Type: "User!"},
+		{Name: "editor", Type: "User"},
+	}, posts.GraphQLRelations(tables))
+	assert.Equal([]GraphQLField{
+		{Name: "posts", Type: "[Post!]!"},
+		{Name: "postsByEditorID", Type: "[Post!]!"},
+	}, users.GraphQLRelations(tables))
+}
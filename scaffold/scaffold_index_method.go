@@ -18,6 +18,13 @@ type (
 		ReturnMany  bool
 		ReturnModel string
 		Desc        bool
+		Count       bool
+		Exists      bool
+		Cursors     CustomMethodParams
+		// IncludingDeleted is true if the method does not filter out the soft deleted rows
+		IncludingDeleted bool
+		// IndexName is the index which the method is backed by
+		IndexName string
 	}
 	// CustomMethodParam ...
 	CustomMethodParam struct {
@@ -26,6 +33,8 @@ type (
 		NameByPascalcase string
 		Type             string
 		Where            bool
+		// FieldName is the model field of the column, empty if the param is not a column
+		FieldName string
 	}
 	// CustomMethodParams ...
 	CustomMethodParams []CustomMethodParam
@@ -61,6 +70,7 @@ func GenCustomMethods(tIndex TemplateDataIndex, modelName string) []CustomMethod
 		// -------------------
 		params = convCustomMethodParams(columns[:i], true)
 		rangeParam := newCustomMethodParam(column.Name, column.Type, false)
+		rangeParam.FieldName = column.NameByPascalcase
 		orders = columns[i:]
 		if last {
 			// e.g. FindByIds(ids...) return many
@@ -70,6 +80,17 @@ func GenCustomMethods(tIndex TemplateDataIndex, modelName string) []CustomMethod
 		// e.g. FindByIdsOrderByIDDesc(limit, rangeFunc...) return many
 		methods = append(methods, genCustomMethod(params, &rangeParam, orders, modelName, false, false)) // ASC
 		methods = append(methods, genCustomMethod(params, &rangeParam, orders, modelName, false, true))  // DESC
+		// -------------------
+		// Count and exists
+		// -------------------
+		// e.g. CountByID(id), ExistsByID(id)
+		params = convCustomMethodParams(columns[:i+1], true)
+		method := genCustomMethod(params, nil, nil, modelName, false, false)
+		methods = append(methods, method.toCount(), method.toExists())
+		// e.g. CountByIDs(ids), ExistsByIDs(ids)
+		params = convCustomMethodParams(columns[:i], true)
+		method = genCustomMethod(params, &rangeParam, nil, modelName, false, false)
+		methods = append(methods, method.toCount(), method.toExists())
 	}
 	res := make([]CustomMethod, 0, len(methods))
 	for _, m := range methods {
@@ -81,6 +102,65 @@ func GenCustomMethods(tIndex TemplateDataIndex, modelName string) []CustomMethod
 	return res
 }
 
+// GenCursorMethods generates the keyset pagination methods for each prefix of the composite index.
+// The primary key is appended to the cursor columns of the non-unique index, so that the order is unique.
+func GenCursorMethods(tIndex TemplateDataIndex, primaryKey TemplateDataIndex, modelName string) []CustomMethod {
+	columns := tIndex.Columns
+	var methods []CustomMethod
+	for i := 0; i < len(columns)-1; i++ {
+		cursors := columns[i+1:]
+		if !tIndex.Unique {
+			for _, pk := range primaryKey.Columns {
+				if !containsColumn(cursors, pk.Name) {
+					cursors = append(cursors[:len(cursors):len(cursors)], pk)
+				}
+			}
+		}
+		nullable := false
+		for _, column := range cursors {
+			nullable = nullable || strings.HasPrefix(column.Type, "null.")
+		}
+		if nullable {
+			continue // null can not be compared in the row value
+		}
+		params := convCustomMethodParams(columns[:i+1], true)
+		// e.g. FindByUserIDAfterCreatedAt(userID, cursor, limit) return many and next cursor
+		// e.g. FindByUserIDBeforeCreatedAt(userID, cursor, limit) return many and next cursor
+		methods = append(methods, genCursorMethod(params, columns[i+1:], cursors, modelName, false)) // ASC
+		methods = append(methods, genCursorMethod(params, columns[i+1:], cursors, modelName, true))  // DESC
+	}
+	return methods
+}
+
+func genCursorMethod(params CustomMethodParams, orders, cursors []TemplateDataColumn, modelName string, desc bool) CustomMethod {
+	method := CustomMethod{}
+	method.Params = append(params[:len(params):len(params)],
+		newCustomMethodParam("cursor", "string", false),
+		newCustomMethodParam("limit", "uint64", false),
+	)
+	method.ReturnModel = modelName
+	method.ReturnMany = true
+	method.Desc = desc
+	method.setName()
+	direction := "After"
+	if desc {
+		direction = "Before"
+	}
+	method.Name += direction + convCustomMethodParams(orders, false).joinName("And")
+	method.Cursors = convCustomMethodParams(cursors, false)
+	method.Orders = method.Cursors
+	return method
+}
+
+func containsColumn(columns []TemplateDataColumn, name string) bool {
+	for _, column := range columns {
+		if column.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func newCustomMethodParam(name, typ string, where bool) CustomMethodParam {
 	wc := helper.NewWordConverter(name)
 	return CustomMethodParam{
@@ -95,6 +175,7 @@ func convCustomMethodParams(cols []TemplateDataColumn, where bool) CustomMethodP
 	params := make(CustomMethodParams, len(cols))
 	for i, col := range cols {
 		params[i] = newCustomMethodParam(col.Name, col.Type, where)
+		params[i].FieldName = col.NameByPascalcase
 	}
 	return params
 }
@@ -123,6 +204,7 @@ func genCustomMethod(params CustomMethodParams, rangeParam *CustomMethodParam, o
 		typ := fmt.Sprintf("[]%s", rangeParam.Type)
 		rp := newCustomMethodParam(name, typ, true)
 		rp.Name = rangeParam.Name // set original name
+		rp.FieldName = rangeParam.FieldName
 		method.Params = append(method.Params, rp)
 		method.RangeParam = nil
 	}
@@ -134,6 +216,20 @@ func genCustomMethod(params CustomMethodParams, rangeParam *CustomMethodParam, o
 	return &method
 }
 
+func (cm CustomMethod) toCount() *CustomMethod {
+	cm.Count = true
+	cm.ReturnMany = false
+	cm.setName()
+	return &cm
+}
+
+func (cm CustomMethod) toExists() *CustomMethod {
+	cm.Exists = true
+	cm.ReturnMany = false
+	cm.setName()
+	return &cm
+}
+
 func (cm *CustomMethod) setName() {
 	var names []string
 	for _, param := range cm.Params {
@@ -144,7 +240,13 @@ func (cm *CustomMethod) setName() {
 	if cm.RangeParam != nil {
 		names = append(names, helper.NewWordConverter(cm.RangeParam.Name).Pascalcase().Pluralize().Lint().ToString())
 	}
-	names = []string{"FindBy", strings.Join(names, "And")}
+	prefix := "FindBy"
+	if cm.Count {
+		prefix = "CountBy"
+	} else if cm.Exists {
+		prefix = "ExistsBy"
+	}
+	names = []string{prefix, strings.Join(names, "And")}
 	if len(cm.Orders) > 0 {
 		ascDesc := "Asc"
 		if cm.Desc {
@@ -131,3 +131,165 @@ func TestScaffoldIndexMethod_getRangeFncType(t *testing.T) {
 		})
 	}
 }
+
+func TestScaffoldIndexMethod_GenCustomMethods_countExists(t *testing.T) {
+	assert := assert.New(t)
+	index := TemplateDataIndex{
+		Columns: []TemplateDataColumn{
+			{Name: "user_id", Type: "int64"},
+			{Name: "item_id", Type: "int64"},
+		},
+		Unique: true,
+	}
+	methods := map[string]CustomMethod{}
+	for _, method := range GenCustomMethods(index, "UserItem") {
+		methods[method.Name] = method
+	}
+	tests := []struct {
+		name   string
+		params []string
+	}{
+		{name: "CountByUserID", params: []string{"userID int64"}},
+		{name: "ExistsByUserID", params: []string{"userID int64"}},
+		{name: "CountByUserIDs", params: []string{"userIDs []int64"}},
+		{name: "ExistsByUserIDs", params: []string{"userIDs []int64"}},
+		{name: "CountByUserIDAndItemID", params: []string{"userID int64", "itemID int64"}},
+		{name: "ExistsByUserIDAndItemID", params: []string{"userID int64", "itemID int64"}},
+		{name: "CountByUserIDAndItemIDs", params: []string{"userID int64", "itemIDs []int64"}},
+		{name: "ExistsByUserIDAndItemIDs", params: []string{"userID int64", "itemIDs []int64"}},
+	}
+	for _, test := range tests {
+		method, ok := methods[test.name]
+		if !assert.True(ok, test.name) {
+			continue
+		}
+		params := make([]string, len(method.Params))
+		for i, p := range method.Params {
+			params[i] = fmt.Sprintf("%s %s", p.NameByCamelcase, p.Type)
+		}
+		assert.Equal(test.params, params, test.name)
+		assert.False(method.ReturnMany, test.name)
+		assert.Empty(method.Orders, test.name)
+		assert.Equal(strings.HasPrefix(test.name, "Count"), method.Count, test.name)
+		assert.Equal(strings.HasPrefix(test.name, "Exists"), method.Exists, test.name)
+	}
+}
+
+func TestScaffoldIndexMethod_GenCursorMethods(t *testing.T) {
+	genTemplateDataColumn := func(name, typ string) TemplateDataColumn {
+		return TemplateDataColumn{
+			Name:             name,
+			NameByCamelcase:  helper.NewWordConverter(name).Camelcase().Lint().ToString(),
+			NameByPascalcase: helper.NewWordConverter(name).Pascalcase().Lint().ToString(),
+			Type:             typ,
+		}
+	}
+	primaryKey := TemplateDataIndex{
+		Columns: []TemplateDataColumn{genTemplateDataColumn("id", "int64")},
+		Primary: true,
+		Unique:  true,
+	}
+	joinNames := func(params CustomMethodParams) string {
+		names := make([]string, len(params))
+		for i, p := range params {
+			names[i] = p.Name
+		}
+		return strings.Join(names, ",")
+	}
+
+	tests := []struct {
+		title   string
+		index   TemplateDataIndex
+		methods map[string]string // name: cursor columns
+	}{
+		{
+			title: "single column",
+			index: TemplateDataIndex{
+				Columns: []TemplateDataColumn{genTemplateDataColumn("user_id", "int64")},
+			},
+			methods: map[string]string{},
+		},
+		{
+			title: "no-uniq:index{user_id,created_at}",
+			index: TemplateDataIndex{
+				Columns: []TemplateDataColumn{
+					genTemplateDataColumn("user_id", "int64"),
+					genTemplateDataColumn("created_at", "time.Time"),
+				},
+			},
+			methods: map[string]string{
+				"FindByUserIDAfterCreatedAt":  "created_at,id",
+				"FindByUserIDBeforeCreatedAt": "created_at,id",
+			},
+		},
+		{
+			title: "uniq:index{user_id,item_id,seq}",
+			index: TemplateDataIndex{
+				Columns: []TemplateDataColumn{
+					genTemplateDataColumn("user_id", "int64"),
+					genTemplateDataColumn("item_id", "int64"),
+					genTemplateDataColumn("seq", "int32"),
+				},
+				Unique: true,
+			},
+			methods: map[string]string{
+				"FindByUserIDAfterItemIDAndSeq":  "item_id,seq",
+				"FindByUserIDBeforeItemIDAndSeq": "item_id,seq",
+				"FindByUserIDAndItemIDAfterSeq":  "seq",
+				"FindByUserIDAndItemIDBeforeSeq": "seq",
+			},
+		},
+		{
+			title: "nullable cursor",
+			index: TemplateDataIndex{
+				Columns: []TemplateDataColumn{
+					genTemplateDataColumn("user_id", "int64"),
+					genTemplateDataColumn("expired_at", "null.Time"),
+				},
+			},
+			methods: map[string]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			assert := assert.New(t)
+			methods := GenCursorMethods(test.index, primaryKey, "UserItem")
+			assert.Len(methods, len(test.methods))
+			for _, method := range methods {
+				cursors, ok := test.methods[method.Name]
+				if !assert.True(ok, method.Name) {
+					continue
+				}
+				assert.Equal(cursors, joinNames(method.Cursors), method.Name)
+				assert.Equal(cursors, joinNames(method.Orders), method.Name)
+				assert.Equal(strings.Contains(method.Name, "Before"), method.Desc, method.Name)
+				assert.True(method.ReturnMany)
+				last := method.Params[len(method.Params)-2:]
+				assert.Equal("cursor,limit", joinNames(last), method.Name)
+			}
+		})
+	}
+}
+
+func TestScaffoldIndexMethod_GenCustomMethods_fieldName(t *testing.T) {
+	assert := assert.New(t)
+	index := TemplateDataIndex{
+		Columns: []TemplateDataColumn{
+			{Name: "user_id", NameByPascalcase: "UserID", Type: "int64"},
+			{Name: "item_id", NameByPascalcase: "ItemID", Type: "int64"},
+		},
+		Unique: true,
+	}
+	for _, method := range GenCustomMethods(index, "UserItem") {
+		for _, p := range method.Params {
+			switch p.Name {
+			case "user_id":
+				assert.Equal("UserID", p.FieldName, method.Name)
+			case "item_id":
+				assert.Equal("ItemID", p.FieldName, method.Name)
+			default:
+				assert.Empty(p.FieldName, method.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package scaffold
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+type (
+	// JSONSchema is a schema of JSON Schema, or of OpenAPI with Nullable
+	JSONSchema struct {
+		Schema               string           `json:"$schema,omitempty"`
+		Title                string           `json:"title,omitempty"`
+		Description          string           `json:"description,omitempty"`
+		Type                 interface{}      `json:"type,omitempty"`
+		Format               string           `json:"format,omitempty"`
+		Nullable             bool             `json:"nullable,omitempty"`
+		MaxLength            *uint            `json:"maxLength,omitempty"`
+		Minimum              json.Number      `json:"minimum,omitempty"`
+		Maximum              json.Number      `json:"maximum,omitempty"`
+		Enum                 []interface{}    `json:"enum,omitempty"`
+		Properties           JSONSchemaFields `json:"properties,omitempty"`
+		Required             []string         `json:"required,omitempty"`
+		AdditionalProperties *bool            `json:"additionalProperties,omitempty"`
+	}
+	// JSONSchemaField is a property of JSON Schema
+	JSONSchemaField struct {
+		Name   string
+		Schema JSONSchema
+	}
+	// JSONSchemaFields is the properties of JSON Schema, which are marshaled in order
+	JSONSchemaFields []JSONSchemaField
+	// OpenAPIComponents is components of OpenAPI
+	OpenAPIComponents struct {
+		Components struct {
+			Schemas JSONSchemaFields `json:"schemas"`
+		} `json:"components"`
+	}
+)
+
+// MarshalJSON marshals the fields as an object in order
+func (fields JSONSchemaFields) MarshalJSON() ([]byte, error) {
+	buff := bytes.NewBufferString("{")
+	enc := json.NewEncoder(buff)
+	enc.SetEscapeHTML(false)
+	for i, field := range fields {
+		if i > 0 {
+			buff.WriteByte(',')
+		}
+		if err := enc.Encode(field.Name); err != nil {
+			return nil, err
+		}
+		buff.WriteByte(':')
+		if err := enc.Encode(field.Schema); err != nil {
+			return nil, err
+		}
+	}
+	buff.WriteByte('}')
+	return buff.Bytes(), nil
+}
+
+// JSONSchema returns JSON Schema of the model of the table
+func (tdt TemplateDataTable) JSONSchema() JSONSchema {
+	schema := tdt.jsonSchema(false)
+	schema.Schema = jsonSchemaDraft
+	return schema
+}
+
+// OpenAPIComponents returns components of OpenAPI which has the schemas of all tables
+func (td TemplateData) OpenAPIComponents() OpenAPIComponents {
+	var res OpenAPIComponents
+	res.Components.Schemas = make(JSONSchemaFields, len(td.Tables))
+	for i, table := range td.Tables {
+		res.Components.Schemas[i] = JSONSchemaField{Name: table.NameByPascalcase, Schema: table.jsonSchema(true)}
+	}
+	return res
+}
+
+// jsonSchema returns the schema, the nullable columns are expressed by nullable of OpenAPI 3.0 if openAPI is true
+func (tdt TemplateDataTable) jsonSchema(openAPI bool) JSONSchema {
+	additional := false
+	schema := JSONSchema{
+		Title:                tdt.NameByPascalcase,
+		Description:          tdt.Name,
+		Type:                 "object",
+		Properties:           make(JSONSchemaFields, len(tdt.Columns)),
+		Required:             []string{},
+		AdditionalProperties: &additional,
+	}
+	for i, column := range tdt.Columns {
+		schema.Properties[i] = JSONSchemaField{Name: column.JSONName, Schema: column.jsonSchema(openAPI)}
+		if !column.IsNullable {
+			schema.Required = append(schema.Required, column.JSONName)
+		}
+	}
+	return schema
+}
+
+func (tdc TemplateDataColumn) jsonSchema(openAPI bool) JSONSchema {
+	mc := tdc.Column
+	schema := JSONSchema{Description: mc.ColumnComment}
+	t := "string"
+	switch mc.DataType {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint", "year", "bit":
+		t = "integer"
+		if mc.DataType != "year" && mc.DataType != "bit" {
+			rng := mc.DataTypeRange()
+			schema.Minimum = json.Number(strconv.FormatInt(rng.Min, 10))
+			schema.Maximum = json.Number(strconv.FormatUint(rng.Max, 10))
+			if !openAPI || rng.Max > math.MaxInt64 {
+				break // no format for uint64
+			} else if rng.Min < math.MinInt32 || rng.Max > math.MaxInt32 {
+				schema.Format = "int64"
+			} else {
+				schema.Format = "int32"
+			}
+		}
+	case "float", "double", "real", "decimal", "dec", "numeric":
+		t = "number"
+	case "date":
+		schema.Format = "date"
+	case "datetime", "timestamp":
+		schema.Format = "date-time"
+	case "time":
+		schema.Format = "time"
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		schema.MaxLength = mc.CharacterMaximumLength
+	case "enum", "set":
+		if mc.DataType == "set" {
+			break
+		}
+		for _, v := range mc.EnumValues() {
+			schema.Enum = append(schema.Enum, v)
+		}
+		if mc.IsNullable && len(schema.Enum) > 0 {
+			schema.Enum = append(schema.Enum, nil)
+		}
+	}
+	schema.Type = t
+	if mc.IsNullable {
+		if openAPI {
+			schema.Nullable = true
+		} else {
+			schema.Type = []string{t, "null"}
+		}
+	}
+	return schema
+}
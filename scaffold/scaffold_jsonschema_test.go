@@ -0,0 +1,85 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suzujun/gendao/dependency"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+func TestScaffold_JSONSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	config.JSONNaming = dependency.JSONNamingSnake
+	table := newTestMysqlTable()
+	table.Columns = append(table.Columns, mysql.Column{
+		TableName:     "users",
+		ColumnName:    "role",
+		DataType:      "enum",
+		ColumnType:    "enum('admin','member')",
+		IsNullable:    true,
+		ColumnComment: "role of <user>",
+	})
+	pTable := NewTamplateParamTable(config, table)
+
+	res, err := toJSON(pTable.JSONSchema())
+	assert.NoError(err)
+	assert.Contains(res, `"$schema": "https://json-schema.org/draft/2020-12/schema"`)
+	assert.Contains(res, `"properties": {
+    "id": {
+      "type": "integer",
+      "minimum": 0,
+      "maximum": 18446744073709551615
+    },
+    "name": {
+      "type": "string",
+      "maxLength": 255
+    },`)
+	assert.Contains(res, `"role": {
+      "description": "role of <user>",
+      "type": [
+        "string",
+        "null"
+      ],
+      "enum": [
+        "admin",
+        "member",
+        null
+      ]
+    }`)
+	assert.NotContains(res, `"role",`)
+
+	res, err = toJSON(TemplateData{Tables: []TemplateDataTable{pTable}}.OpenAPIComponents())
+	assert.NoError(err)
+	assert.Contains(res, `"schemas": {
+      "User": {`)
+	assert.Contains(res, `"type": "string",
+            "nullable": true,`)
+	assert.NotContains(res, "$schema")
+
+	// the names are the field names without jsonNaming, same as encoding/json
+	config.JSONNaming = ""
+	pTable = NewTamplateParamTable(config, table)
+	assert.Equal("ID", pTable.JSONSchema().Properties[0].Name)
+}
+
+func TestScaffold_JSONSchema_format(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		column mysql.Column
+		format string
+	}{
+		{mysql.Column{DataType: "int", ColumnType: "int(11)"}, "int32"},
+		{mysql.Column{DataType: "int", ColumnType: "int(10) unsigned"}, "int64"},
+		{mysql.Column{DataType: "bigint", ColumnType: "bigint(20) unsigned"}, ""},
+		{mysql.Column{DataType: "date", ColumnType: "date"}, "date"},
+		{mysql.Column{DataType: "timestamp", ColumnType: "timestamp"}, "date-time"},
+	}
+	for _, tt := range tests {
+		column := TemplateDataColumn{Column: tt.column}
+		assert.Equal(tt.format, column.jsonSchema(true).Format, tt.column.ColumnType)
+	}
+}
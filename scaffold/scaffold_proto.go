@@ -0,0 +1,98 @@
+package scaffold
+
+import (
+	"fmt"
+)
+
+// protoType is the mapping of the column type to protobuf
+type protoType struct {
+	// name is the type of protobuf
+	name string
+	// optional is true if the field has presence, which is a pointer in Go
+	optional bool
+	// toProto and fromProto are the formats of the conversion between the model field and the proto field
+	toProto   string
+	fromProto string
+}
+
+var protoTypes = map[string]protoType{
+	"string":      {name: "string", toProto: "%s", fromProto: "%s"},
+	"int8":        {name: "int32", toProto: "int32(%s)", fromProto: "int8(%s)"},
+	"int16":       {name: "int32", toProto: "int32(%s)", fromProto: "int16(%s)"},
+	"int32":       {name: "int32", toProto: "%s", fromProto: "%s"},
+	"int64":       {name: "int64", toProto: "%s", fromProto: "%s"},
+	"uint8":       {name: "uint32", toProto: "uint32(%s)", fromProto: "uint8(%s)"},
+	"uint16":      {name: "uint32", toProto: "uint32(%s)", fromProto: "uint16(%s)"},
+	"uint32":      {name: "uint32", toProto: "%s", fromProto: "%s"},
+	"uint64":      {name: "uint64", toProto: "%s", fromProto: "%s"},
+	"float32":     {name: "float", toProto: "%s", fromProto: "%s"},
+	"float64":     {name: "double", toProto: "%s", fromProto: "%s"},
+	"time.Time":   {name: "google.protobuf.Timestamp", toProto: "timestamppb.New(%s)", fromProto: "%s.AsTime()"},
+	"null.String": {name: "string", optional: true, toProto: "%s.Ptr()", fromProto: "null.StringFromPtr(%s)"},
+	"null.Int":    {name: "int64", optional: true, toProto: "%s.Ptr()", fromProto: "null.IntFromPtr(%s)"},
+	"null.Float":  {name: "double", optional: true, toProto: "%s.Ptr()", fromProto: "null.FloatFromPtr(%s)"},
+	"null.Time":   {name: "google.protobuf.Timestamp", toProto: "nullTimeToProto(%s)", fromProto: "nullTimeFromProto(%s)"},
+}
+
+// ProtoType returns the field type of protobuf with optional label, or empty if the type of the column is not supported
+func (tdc TemplateDataColumn) ProtoType() string {
+	t, ok := protoTypes[tdc.Type]
+	if !ok {
+		return ""
+	} else if t.optional {
+		return "optional " + t.name
+	}
+	return t.name
+}
+
+// ProtoGoName returns the field name of the Go struct generated by protoc-gen-go, e.g. user_id is UserId
+func (tdc TemplateDataColumn) ProtoGoName() string {
+	return protoGoCamelcase(tdc.Name)
+}
+
+// ToProto returns the expression to convert v of the model field to the proto field
+func (tdc TemplateDataColumn) ToProto(v string) string {
+	return fmt.Sprintf(protoTypes[tdc.Type].toProto, v)
+}
+
+// FromProto returns the expression to convert v of the proto field to the model field
+func (tdc TemplateDataColumn) FromProto(v string) string {
+	return fmt.Sprintf(protoTypes[tdc.Type].fromProto, v)
+}
+
+// ProtoUseTimestamp returns true if the message of the table has google.protobuf.Timestamp
+func (tdt TemplateDataTable) ProtoUseTimestamp() bool {
+	for _, column := range tdt.Columns {
+		if column.Type == "time.Time" || column.Type == "null.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// protoGoCamelcase converts the name same as protoc-gen-go
+func protoGoCamelcase(s string) string {
+	isLower := func(c byte) bool { return 'a' <= c && c <= 'z' }
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X') // initial '_' is converted to start with a capital letter
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+			// skip '_' followed by lowercase
+		case isDigit(c):
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,55 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suzujun/gendao/dependency"
+)
+
+func TestScaffold_protoGoCamelcase(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("UserId", protoGoCamelcase("user_id"))
+	assert.Equal("CreatedAt", protoGoCamelcase("created_at"))
+	assert.Equal("Address2", protoGoCamelcase("address2"))
+	assert.Equal("Foo_1Bar", protoGoCamelcase("foo_1bar"))
+	assert.Equal("XFoo", protoGoCamelcase("_foo"))
+	assert.Equal("FOO", protoGoCamelcase("FOO"))
+}
+
+func TestScaffold_ProtoType(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	table := NewTamplateParamTable(config, newTestMysqlTable())
+	columns := map[string]TemplateDataColumn{}
+	for _, column := range table.Columns {
+		columns[column.Name] = column
+	}
+	assert.Equal("uint64", columns["id"].ProtoType())
+	assert.Equal("string", columns["name"].ProtoType())
+	assert.Equal("google.protobuf.Timestamp", columns["created_at"].ProtoType())
+	assert.Equal("timestamppb.New(m.CreatedAt)", columns["created_at"].ToProto("m.CreatedAt"))
+	assert.Equal("p.CreatedAt.AsTime()", columns["created_at"].FromProto("p.CreatedAt"))
+	assert.True(table.ProtoUseTimestamp())
+
+	tests := []struct {
+		column    TemplateDataColumn
+		protoType string
+		toProto   string
+		fromProto string
+	}{
+		{TemplateDataColumn{Type: "int8"}, "int32", "int32(v)", "int8(v)"},
+		{TemplateDataColumn{Type: "null.String"}, "optional string", "v.Ptr()", "null.StringFromPtr(v)"},
+		{TemplateDataColumn{Type: "null.Time"}, "google.protobuf.Timestamp", "nullTimeToProto(v)", "nullTimeFromProto(v)"},
+		{TemplateDataColumn{Type: "interface{}"}, "", "", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(tt.protoType, tt.column.ProtoType(), tt.column.Type)
+		if tt.protoType != "" {
+			assert.Equal(tt.toProto, tt.column.ToProto("v"), tt.column.Type)
+			assert.Equal(tt.fromProto, tt.column.FromProto("v"), tt.column.Type)
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package scaffold
 
 import (
+	"bytes"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"io/ioutil"
 	"path/filepath"
 	"testing"
@@ -136,3 +141,364 @@ func TestScaffold_setType(t *testing.T) {
 		require.NoError(err)
 	}
 }
+
+func TestScaffold_InsertColumns(t *testing.T) {
+	tests := []struct {
+		title string
+		table TemplateDataTable
+		want  []string
+	}{
+		{
+			title: "auto increment primary key",
+			table: TemplateDataTable{
+				Columns: []TemplateDataColumn{
+					{Name: "id", Primary: true, AutoIncrement: true},
+					{Name: "name"},
+					{Name: "created_at", Common: true},
+				},
+				PrimaryKey: TemplateDataIndex{AutoIncrement: true, HasAutoIncrement: true},
+			},
+			want: []string{"name", "created_at"},
+		},
+		{
+			title: "primary key without auto increment",
+			table: TemplateDataTable{
+				Columns: []TemplateDataColumn{
+					{Name: "user_id", Primary: true},
+					{Name: "item_id", Primary: true},
+					{Name: "quantity"},
+				},
+			},
+			want: []string{"user_id", "item_id", "quantity"},
+		},
+		{
+			title: "composite primary key with auto increment",
+			table: TemplateDataTable{
+				Columns: []TemplateDataColumn{
+					{Name: "user_id", Primary: true},
+					{Name: "seq", Primary: true, AutoIncrement: true},
+					{Name: "body"},
+				},
+				PrimaryKey: TemplateDataIndex{HasAutoIncrement: true},
+			},
+			want: []string{"user_id", "body"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			assert := assert.New(t)
+			columns := test.table.InsertColumns()
+			names := make([]string, len(columns))
+			for i, column := range columns {
+				names[i] = column.Name
+			}
+			assert.Equal(test.want, names)
+		})
+	}
+}
+
+func newTestMysqlTable() mysql.Table {
+	length := uint(255)
+	column := func(name, dataType, columnType, key, extra string) mysql.Column {
+		return mysql.Column{
+			TableName:              "users",
+			ColumnName:             name,
+			DataType:               dataType,
+			ColumnType:             columnType,
+			ColumnKey:              key,
+			Extra:                  extra,
+			CharacterMaximumLength: &length,
+		}
+	}
+	index := func(name, column string, seq, nonUnique uint) mysql.Index {
+		return mysql.Index{TableName: "users", IndexName: name, ColumnName: column, SeqInIndex: seq, NonUnique: nonUnique}
+	}
+	return mysql.Table{
+		Name: "users",
+		Columns: []mysql.Column{
+			column("id", "bigint", "bigint(20) unsigned", "PRI", "auto_increment"),
+			column("name", "varchar", "varchar(255)", "", ""),
+			column("email", "varchar", "varchar(255)", "UNI", ""),
+			column("status", "varchar", "varchar(255)", "MUL", ""),
+			column("created_at", "datetime", "datetime", "", ""),
+			column("updated_at", "datetime", "datetime", "", ""),
+		},
+		Indexes: []mysql.Index{
+			index("PRIMARY", "id", 1, 0),
+			index("uniq_email", "email", 1, 0),
+			index("idx_status_created_at", "status", 1, 1),
+			index("idx_status_created_at", "created_at", 2, 1),
+		},
+	}
+}
+
+func TestScaffold_NewTamplateParamTable_sampleValue(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	table := NewTamplateParamTable(config, newTestMysqlTable())
+	values := map[string]string{}
+	for _, column := range table.Columns {
+		values[column.Name] = column.SampleValue
+	}
+	assert.Equal("uint64(dummy.Uint64(r, 0, 18446744073709551615))", values["id"])
+	assert.Equal("dummy.String(r, 85, 255)", values["name"])
+	assert.Equal("dummy.Time(r, 0)", values["created_at"])
+
+	length, octet, precision, scale := uint(255), uint(100), uint(5), uint(2)
+	columns := []struct {
+		column   mysql.Column
+		expected string
+	}{
+		{mysql.Column{DataType: "varchar", ColumnType: "varchar(255)", CharacterMaximumLength: &length, CharacterOctetLength: &octet}, "dummy.String(r, 33, 100)"},
+		{mysql.Column{DataType: "enum", ColumnType: "enum('a','b')", CharacterMaximumLength: &length, IsNullable: true}, `dummy.NullEnum(r, "a", "b")`},
+		{mysql.Column{DataType: "smallint", ColumnType: "smallint(6)"}, "int16(dummy.Int64(r, -32768, 32767))"},
+		{mysql.Column{DataType: "mediumint", ColumnType: "mediumint(8) unsigned"}, "uint32(dummy.Uint64(r, 0, 16777215))"},
+		{mysql.Column{DataType: "bigint", ColumnType: "bigint(20) unsigned", IsNullable: true}, "dummy.NullInt64(r, 0, 9223372036854775807)"},
+		{mysql.Column{DataType: "decimal", ColumnType: "decimal(5,2)", NumericPrecision: &precision, NumericScale: &scale}, "dummy.Decimal(r, 5, 2)"},
+		{mysql.Column{DataType: "decimal", ColumnType: "decimal(5,2) unsigned", NumericPrecision: &precision, NumericScale: &scale}, "dummy.UnsignedDecimal(r, 5, 2)"},
+		{mysql.Column{DataType: "decimal", ColumnType: "decimal(5,2) unsigned", NumericPrecision: &precision, NumericScale: &scale, IsNullable: true}, "dummy.NullUnsignedDecimal(r, 5, 2)"},
+		{mysql.Column{DataType: "double", ColumnType: "double"}, "dummy.Float64(r, -1000, 1000)"},
+		{mysql.Column{DataType: "double", ColumnType: "double unsigned"}, "dummy.Float64(r, 0, 1000)"},
+		{mysql.Column{DataType: "float", ColumnType: "float unsigned", IsNullable: true}, "dummy.NullFloat64(r, 0, 1000)"},
+		{mysql.Column{DataType: "datetime", ColumnType: "datetime(6)", DatetimePrecision: &precision, IsNullable: true}, "dummy.NullTime(r, 5)"},
+		{mysql.Column{DataType: "date", ColumnType: "date"}, "dummy.Date(r)"},
+	}
+	for _, c := range columns {
+		column := newTemplateParamColumn(c.column, nil, nil)
+		assert.Equal(c.expected, column.SampleValue, c.column.ColumnType)
+	}
+}
+
+func TestScaffold_NewTamplateParamTable_upsertColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	table := NewTamplateParamTable(config, newTestMysqlTable())
+	names := func(columns []TemplateDataColumn) []string {
+		res := make([]string, len(columns))
+		for i, column := range columns {
+			res[i] = column.Name
+		}
+		return res
+	}
+
+	assert.Equal([]string{"name", "email", "status", "updated_at"}, names(table.UpsertColumns))
+	uniqueIndexes := table.UniqueIndexes()
+	if assert.Len(uniqueIndexes, 1) {
+		assert.Equal("Email", uniqueIndexes[0].ColumnsNameByPascalcase)
+		assert.Equal([]string{"name", "status", "updated_at"}, names(uniqueIndexes[0].UpsertColumns))
+	}
+	if column := table.AutoIncrementColumn(); assert.NotNil(column) {
+		assert.Equal("id", column.Name)
+	}
+	assert.Equal([]string{"updated_at"}, names(table.PreUpdateColumns()))
+
+	// created_at is not refreshed on update even if it is upserted
+	config.UpsertIgnoreColumns = []string{}
+	table = NewTamplateParamTable(config, newTestMysqlTable())
+	assert.Equal([]string{"name", "email", "status", "created_at", "updated_at"}, names(table.UpsertColumns))
+	assert.Equal([]string{"updated_at"}, names(table.PreUpdateColumns()))
+}
+
+func TestScaffold_newTemplateParamIndex_compositePrimaryKey(t *testing.T) {
+	assert := assert.New(t)
+
+	columns := []TemplateDataColumn{
+		{Name: "user_id", NameByPascalcase: "UserID", Primary: true},
+		{Name: "seq", NameByPascalcase: "Seq", Primary: true, AutoIncrement: true},
+		{Name: "name", NameByPascalcase: "Name"},
+	}
+	indexes := []mysql.Index{
+		{IndexName: "PRIMARY", ColumnName: "user_id", SeqInIndex: 1},
+		{IndexName: "PRIMARY", ColumnName: "seq", SeqInIndex: 2},
+	}
+	pIndexes := newTemplateParamIndex(indexes, columns)
+	if assert.Len(pIndexes, 1) {
+		assert.True(pIndexes[0].Primary)
+		// gorp panics with the composite auto increment key
+		assert.False(pIndexes[0].AutoIncrement)
+		assert.True(pIndexes[0].HasAutoIncrement)
+		assert.Len(pIndexes[0].Columns, 2)
+		assert.Equal("UserIDAndSeq", pIndexes[0].ColumnsNameByPascalcase)
+	}
+}
+
+func TestScaffold_NewTamplateParamTable_versionColumn(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	config.VersionColumn = "version"
+	table := newTestMysqlTable()
+	table.Columns = append(table.Columns, mysql.Column{
+		TableName:  "users",
+		ColumnName: "version",
+		DataType:   "bigint",
+		ColumnType: "bigint(20) unsigned",
+	})
+	pTable := NewTamplateParamTable(config, table)
+	column := pTable.VersionColumn()
+	if assert.NotNil(column) {
+		assert.Equal("version", column.Name)
+		assert.Equal("int64", column.Type)
+		assert.Equal("1", column.SampleValue)
+	}
+	for _, column := range pTable.UpsertColumns {
+		assert.NotEqual("version", column.Name)
+	}
+	for _, column := range pTable.PreUpdateColumns() {
+		assert.NotEqual("version", column.Name)
+	}
+
+	// not configured
+	pTable = NewTamplateParamTable(dependency.NewConfig("", "", "", "", ""), table)
+	assert.Nil(pTable.VersionColumn())
+}
+
+func TestScaffold_NewTamplateParamTable_softDeleteColumn(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	config.SoftDeleteColumn = "deleted_at"
+	table := newTestMysqlTable()
+	table.Columns = append(table.Columns, mysql.Column{
+		TableName:  "users",
+		ColumnName: "deleted_at",
+		DataType:   "datetime",
+		ColumnType: "datetime",
+		IsNullable: true,
+	})
+	pTable := NewTamplateParamTable(config, table)
+	column := pTable.SoftDeleteColumn()
+	if assert.NotNil(column) {
+		assert.Equal("deleted_at", column.Name)
+		assert.Equal("null.Time{}", column.SampleValue)
+	}
+	if assert.True(len(pTable.CustomMethods) >= 2) {
+		assert.Equal("FindByID", pTable.CustomMethods[0].Name)
+		assert.False(pTable.CustomMethods[0].IncludingDeleted)
+		assert.Equal("FindByIDIncludingDeleted", pTable.CustomMethods[1].Name)
+		assert.True(pTable.CustomMethods[1].IncludingDeleted)
+	}
+	for _, column := range pTable.PreUpdateColumns() {
+		assert.NotEqual("deleted_at", column.Name)
+	}
+
+	// SoftDelete and Restore do not overwrite created_at even if it is upserted
+	config.UpsertIgnoreColumns = []string{}
+	pTable = NewTamplateParamTable(config, table)
+	if assert.Len(pTable.PreUpdateColumns(), 1) {
+		assert.Equal("updated_at", pTable.PreUpdateColumns()[0].Name)
+	}
+
+	// not nullable
+	table.Columns[len(table.Columns)-1].IsNullable = false
+	pTable = NewTamplateParamTable(config, table)
+	assert.Nil(pTable.SoftDeleteColumn())
+}
+
+// daoTestStubs are the packages used by the generated dao test of logs, to type check it without the generated dao and model
+var daoTestStubs = []struct{ path, src string }{
+	{"testing", `package testing
+type T struct{}
+func (*T) Run(name string, f func(t *T)) bool
+func (*T) Fatalf(format string, args ...interface{})
+func (*T) Errorf(format string, args ...interface{})`},
+	{"example.com/app/model", `package model
+type Log struct{ Name, Email, Status string }
+func NewDummyLog() Log
+func ReportDummySeed(t interface{})`},
+}
+
+// daoTestStubDao is the dao of logs used by the generated dao test
+const daoTestStubDao = `package dao
+import "example.com/app/model"
+type logDao struct{}
+func newTestDaos(t interface{}) struct{ Log logDao }
+func (logDao) Insert(m *model.Log) error
+func (logDao) FindByStatus(status string) ([]*model.Log, error)
+func (logDao) FindByStatuses(statuses []string) ([]*model.Log, error)
+func (logDao) FindByStatusesOrderByStatusAsc(limit int) ([]*model.Log, error)
+func (logDao) FindByStatusesOrderByStatusDesc(limit int) ([]*model.Log, error)
+func (logDao) CountByStatus(status string) (int64, error)
+func (logDao) CountByStatuses(statuses []string) (int64, error)
+func (logDao) ExistsByStatus(status string) (bool, error)
+func (logDao) ExistsByStatuses(statuses []string) (bool, error)`
+
+// stubImporter imports the packages checked from the stubs
+type stubImporter map[string]*types.Package
+
+func (im stubImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := im[path]; ok {
+		return pkg, nil
+	}
+	return nil, fmt.Errorf("no stub of %s", path)
+}
+
+func TestScaffold_daoTestTemplate_withoutPrimaryKey(t *testing.T) {
+	require := require.New(t)
+
+	table := mysql.Table{
+		Name: "logs",
+		Columns: []mysql.Column{
+			{TableName: "logs", ColumnName: "name", DataType: "varchar", ColumnType: "varchar(255)"},
+			{TableName: "logs", ColumnName: "email", DataType: "varchar", ColumnType: "varchar(255)"},
+			{TableName: "logs", ColumnName: "status", DataType: "varchar", ColumnType: "varchar(255)", ColumnKey: "MUL"},
+		},
+		Indexes: []mysql.Index{{TableName: "logs", IndexName: "idx_status", ColumnName: "status", SeqInIndex: 1, NonUnique: 1}},
+	}
+	config := dependency.NewConfig("", "", "", "", "test-db")
+	config.PackageRoot = "example.com/app"
+	pTable := NewTamplateParamTable(config, table)
+	require.Empty(pTable.PrimaryKey.Columns)
+
+	tmpl, err := ParseTemplates("../template", []dependency.TemplateFile{{Name: "dao_xxx_gen_test.tpl"}})
+	require.NoError(err)
+	buff := bytes.NewBuffer([]byte{})
+	require.NoError(tmpl.ExecuteTemplate(buff, "dao_xxx_gen_test.tpl", TemplateData{Config: config, Table: pTable}))
+
+	// type check the generated test with the stubs
+	fset := token.NewFileSet()
+	im := stubImporter{}
+	for _, stub := range daoTestStubs {
+		file, err := parser.ParseFile(fset, stub.path+".go", stub.src, 0)
+		require.NoError(err)
+		conf := types.Config{Importer: im}
+		im[stub.path], err = conf.Check(stub.path, fset, []*ast.File{file}, nil)
+		require.NoError(err)
+	}
+	stubFile, err := parser.ParseFile(fset, "stub_test.go", daoTestStubDao, 0)
+	require.NoError(err)
+	file, err := parser.ParseFile(fset, "log_gen_test.go", buff.Bytes(), 0)
+	require.NoError(err, buff.String())
+	conf := types.Config{Importer: im}
+	_, err = conf.Check("dao", fset, []*ast.File{stubFile, file}, nil)
+	require.NoError(err, buff.String())
+}
+
+func TestScaffold_daoTemplate_cursorWithZeroLimit(t *testing.T) {
+	require := require.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "test-db")
+	config.PackageRoot = "example.com/app"
+	pTable := NewTamplateParamTable(config, newTestMysqlTable())
+	tmpl, err := ParseTemplates("../template", []dependency.TemplateFile{
+		{Name: "part_method_name.tpl"}, {Name: "dao_xxx_gen.tpl"}, {Name: "dao_xxx_gen_test.tpl"},
+	})
+	require.NoError(err)
+	render := func(name string) string {
+		buff := bytes.NewBuffer([]byte{})
+		require.NoError(tmpl.ExecuteTemplate(buff, name, TemplateData{Config: config, Table: pTable}))
+		return buff.String()
+	}
+
+	// the cursor of the last row is not read from the empty result of limit 0
+	dao := render("dao_xxx_gen.tpl")
+	require.Contains(dao, "func (dao UserDao) FindByStatusAfterCreatedAt(status string, cursor string, limit uint64)")
+	require.Contains(dao, "if err != nil || len(users) == 0 || uint64(len(users)) < limit {")
+	require.NotContains(dao, "if err != nil || uint64(len(users)) < limit {")
+
+	test := render("dao_xxx_gen_test.tpl")
+	require.Contains(test, `dao.FindByStatusAfterCreatedAt(m.Status, "", 0)`)
+	require.Contains(test, `dao.FindByStatusBeforeCreatedAt(m.Status, "", 0)`)
+}
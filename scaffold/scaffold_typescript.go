@@ -0,0 +1,116 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxSafeInteger is Number.MAX_SAFE_INTEGER of JavaScript
+const maxSafeInteger = 1<<53 - 1
+
+var tsIdentifierReg = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TSName returns the property name of TypeScript, which is quoted if it is not an identifier
+func (tdc TemplateDataColumn) TSName() string {
+	if tsIdentifierReg.MatchString(tdc.JSONName) {
+		return tdc.JSONName
+	}
+	return tsQuote(tdc.JSONName)
+}
+
+// TSDoc returns the comment of the column as TSDoc, or empty if no comment
+func (tdc TemplateDataColumn) TSDoc() string {
+	if tdc.Column.ColumnComment == "" {
+		return ""
+	}
+	return "/** " + strings.Replace(tdc.Column.ColumnComment, "*/", "*\\/", -1) + " */"
+}
+
+// TSType returns the type of TypeScript, the enum is the union of the string literals.
+// The integers out of the safe integer are bigint, which needs to be parsed from JSON with care.
+func (tdc TemplateDataColumn) TSType() string {
+	t := tdc.tsNamedType()
+	if tdc.IsNullable {
+		return t + " | null"
+	}
+	return t
+}
+
+func (tdc TemplateDataColumn) tsNamedType() string {
+	mc := tdc.Column
+	switch mc.DataType {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint":
+		if tdc.tsBigint() {
+			return "bigint"
+		}
+		return "number"
+	case "float", "double", "real", "decimal", "dec", "numeric", "year", "bit":
+		return "number"
+	case "json":
+		return "unknown"
+	case "enum":
+		if values := mc.EnumValues(); len(values) > 0 {
+			quoted := make([]string, len(values))
+			for i, v := range values {
+				quoted[i] = tsQuote(v)
+			}
+			return strings.Join(quoted, " | ")
+		}
+	}
+	return "string"
+}
+
+func (tdc TemplateDataColumn) tsBigint() bool {
+	rng := tdc.Column.DataTypeRange()
+	return rng.Min < -maxSafeInteger || rng.Max > maxSafeInteger
+}
+
+// ZodType returns the schema of zod, which has the constraints of the column
+func (tdc TemplateDataColumn) ZodType() string {
+	mc := tdc.Column
+	var t string
+	switch mc.DataType {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint":
+		rng := mc.DataTypeRange()
+		if tdc.tsBigint() {
+			t = fmt.Sprintf("z.bigint().min(%dn).max(%dn)", rng.Min, rng.Max)
+		} else {
+			t = fmt.Sprintf("z.number().int().min(%d).max(%d)", rng.Min, rng.Max)
+		}
+	case "float", "double", "real", "decimal", "dec", "numeric":
+		t = "z.number()"
+	case "year", "bit":
+		t = "z.number().int()"
+	case "json":
+		t = "z.unknown()"
+	case "date", "datetime", "timestamp":
+		t = "z.string().datetime({ offset: true })" // time.Time is RFC 3339 in JSON
+	case "enum":
+		if values := mc.EnumValues(); len(values) > 0 {
+			quoted := make([]string, len(values))
+			for i, v := range values {
+				quoted[i] = tsQuote(v)
+			}
+			t = "z.enum([" + strings.Join(quoted, ", ") + "])"
+		}
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		if mc.CharacterMaximumLength != nil {
+			t = fmt.Sprintf("z.string().max(%d)", *mc.CharacterMaximumLength)
+		}
+	}
+	if t == "" {
+		t = "z.string()"
+	}
+	if mc.IsNullable {
+		return t + ".nullable()"
+	}
+	return t
+}
+
+// tsQuote returns the string literal of TypeScript
+func tsQuote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,61 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suzujun/gendao/dependency"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+func TestScaffold_TSType(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		column mysql.Column
+		tsType string
+		zod    string
+	}{
+		{mysql.Column{DataType: "int", ColumnType: "int(11)"}, "number", "z.number().int().min(-2147483648).max(2147483647)"},
+		{mysql.Column{DataType: "bigint", ColumnType: "bigint(20)"}, "bigint", "z.bigint().min(-9223372036854775808n).max(9223372036854775807n)"},
+		{mysql.Column{DataType: "int", ColumnType: "int(11)", IsNullable: true}, "number | null", "z.number().int().min(-2147483648).max(2147483647).nullable()"},
+		{mysql.Column{DataType: "decimal", ColumnType: "decimal(5,2)"}, "number", "z.number()"},
+		{mysql.Column{DataType: "enum", ColumnType: "enum('a','b')"}, `"a" | "b"`, `z.enum(["a", "b"])`},
+		{mysql.Column{DataType: "datetime", ColumnType: "datetime", IsNullable: true}, "string | null", "z.string().datetime({ offset: true }).nullable()"},
+		{mysql.Column{DataType: "json", ColumnType: "json"}, "unknown", "z.unknown()"},
+		{mysql.Column{DataType: "blob", ColumnType: "blob"}, "string", "z.string()"},
+	}
+	for _, tt := range tests {
+		column := TemplateDataColumn{Column: tt.column}
+		assert.Equal(tt.tsType, column.TSType(), tt.column.ColumnType)
+		assert.Equal(tt.zod, column.ZodType(), tt.column.ColumnType)
+	}
+}
+
+func TestScaffold_TSName(t *testing.T) {
+	assert := assert.New(t)
+
+	config := dependency.NewConfig("", "", "", "", "")
+	table := newTestMysqlTable()
+	table.Columns = append(table.Columns, mysql.Column{TableName: "users", ColumnName: "2fa", DataType: "tinyint", ColumnType: "tinyint(1)"})
+
+	tests := []struct {
+		naming string
+		names  []string
+	}{
+		{"", []string{"ID", "CreatedAt"}},
+		{dependency.JSONNamingSnake, []string{"id", "created_at", `"2fa"`}},
+		{dependency.JSONNamingCamel, []string{"id", "createdAt"}},
+	}
+	for _, tt := range tests {
+		config.JSONNaming = tt.naming
+		pTable := NewTamplateParamTable(config, table)
+		names := map[string]bool{}
+		for _, column := range pTable.Columns {
+			names[column.TSName()] = true
+		}
+		for _, name := range tt.names {
+			assert.True(names[name], name)
+		}
+	}
+}
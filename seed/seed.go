@@ -0,0 +1,313 @@
+package seed
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+const (
+	// chunkSize is the number of rows in an INSERT statement
+	chunkSize = 100
+	// maxParentKeys is the number of parent keys to pick the foreign key from
+	maxParentKeys = 1000
+	// maxRetry is the number of retries to generate the row which is not duplicated in the unique indexes
+	maxRetry = 10
+)
+
+type (
+	// Seeder inserts dummy rows into the tables
+	Seeder struct {
+		db   *sql.DB
+		rand *rand.Rand
+	}
+	// foreignKey is a foreign key constraint, which may have multiple columns
+	foreignKey struct {
+		columns           []string
+		referencedTable   string
+		referencedColumns []string
+	}
+)
+
+// NewSeeder generate new seeder, the values are reproducible with the same seed
+func NewSeeder(db *sql.DB, seed int64) *Seeder {
+	return &Seeder{db: db, rand: rand.New(rand.NewSource(seed))}
+}
+
+// SortTables sorts the tables so that the referenced tables by the foreign keys come first.
+// The tables in a cycle are kept in the original order.
+func SortTables(tables []mysql.Table) []mysql.Table {
+	tableMap := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		tableMap[table.Name] = true
+	}
+	sorted := make([]mysql.Table, 0, len(tables))
+	done := make(map[string]bool, len(tables))
+	for len(sorted) < len(tables) {
+		progress := false
+		for _, table := range tables {
+			if done[table.Name] {
+				continue
+			}
+			ready := true
+			for _, fk := range table.ForeignKeys {
+				parent := fk.ReferencedTableName
+				if parent != table.Name && tableMap[parent] && !done[parent] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				sorted = append(sorted, table)
+				done[table.Name] = true
+				progress = true
+			}
+		}
+		if !progress {
+			// cycle: take the first remaining table
+			for _, table := range tables {
+				if !done[table.Name] {
+					sorted = append(sorted, table)
+					done[table.Name] = true
+					break
+				}
+			}
+		}
+	}
+	return sorted
+}
+
+// SeedTable inserts the rows into the table, and returns the number of inserted rows.
+// The rows duplicated with the existing rows are ignored.
+func (s *Seeder) SeedTable(table mysql.Table, rows int) (int64, error) {
+	columns := insertColumns(table)
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("no columns to insert [table=%s]", table.Name)
+	}
+	columnIndex := make(map[string]int, len(columns))
+	for i, column := range columns {
+		columnIndex[column.ColumnName] = i
+	}
+
+	// parent keys of the foreign keys
+	fks := foreignKeys(table)
+	parentKeys := make([][][]interface{}, len(fks))
+	for i, fk := range fks {
+		keys, err := s.selectKeys(fk.referencedTable, fk.referencedColumns)
+		if err != nil {
+			return 0, err
+		}
+		parentKeys[i] = keys
+	}
+
+	uniques := uniqueIndexes(table, columnIndex)
+	seen := make([]map[string]bool, len(uniques))
+	for i := range seen {
+		seen[i] = map[string]bool{}
+	}
+
+	values := make([][]interface{}, 0, rows)
+	for n := 0; n < rows; n++ {
+		for retry := 0; retry < maxRetry; retry++ {
+			row, err := s.newRow(table, columns, columnIndex, fks, parentKeys)
+			if err != nil {
+				return 0, err
+			}
+			if markUnique(row, uniques, seen) {
+				values = append(values, row)
+				break
+			}
+		}
+	}
+
+	var inserted int64
+	for start := 0; start < len(values); start += chunkSize {
+		end := start + chunkSize
+		if end > len(values) {
+			end = len(values)
+		}
+		n, err := s.insert(table.Name, columns, values[start:end])
+		if err != nil {
+			return inserted, err
+		}
+		inserted += n
+	}
+	return inserted, nil
+}
+
+func (s *Seeder) newRow(table mysql.Table, columns []mysql.Column, columnIndex map[string]int, fks []foreignKey, parentKeys [][][]interface{}) ([]interface{}, error) {
+	row := make([]interface{}, len(columns))
+	for i, column := range columns {
+		row[i] = Value(s.rand, column)
+	}
+	for i, fk := range fks {
+		keys := parentKeys[i]
+		if len(keys) == 0 {
+			nullable := true
+			for _, name := range fk.columns {
+				nullable = nullable && columns[columnIndex[name]].IsNullable
+			}
+			if !nullable {
+				return nil, fmt.Errorf("no rows to refer in %s [table=%s]", fk.referencedTable, table.Name)
+			}
+			for _, name := range fk.columns {
+				row[columnIndex[name]] = nil
+			}
+			continue
+		}
+		key := keys[s.rand.Intn(len(keys))]
+		for j, name := range fk.columns {
+			row[columnIndex[name]] = key[j]
+		}
+	}
+	return row, nil
+}
+
+// selectKeys selects the existing keys of the table
+func (s *Seeder) selectKeys(table string, columns []string) ([][]interface{}, error) {
+	query := fmt.Sprintf("SELECT DISTINCT %s FROM %s LIMIT %d", quoteJoin(columns), quote(table), maxParentKeys)
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "select keys failed [sql='%s']", query)
+	}
+	defer rows.Close()
+	var keys [][]interface{}
+	for rows.Next() {
+		key := make([]interface{}, len(columns))
+		dests := make([]interface{}, len(columns))
+		for i := range key {
+			dests[i] = &key[i]
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return nil, errors.Wrapf(err, "scan keys failed [sql='%s']", query)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *Seeder) insert(table string, columns []mysql.Column, rows [][]interface{}) (int64, error) {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.ColumnName
+	}
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+	var query bytes.Buffer
+	fmt.Fprintf(&query, "INSERT IGNORE INTO %s (%s) VALUES ", quote(table), quoteJoin(names))
+	args := make([]interface{}, 0, len(rows)*len(columns))
+	for i, row := range rows {
+		if i > 0 {
+			query.WriteByte(',')
+		}
+		query.WriteString(placeholder)
+		args = append(args, row...)
+	}
+	res, err := s.db.Exec(query.String(), args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "insert failed [table=%s]", table)
+	}
+	return res.RowsAffected()
+}
+
+// insertColumns returns the columns except the auto increment and generated columns
+func insertColumns(table mysql.Table) []mysql.Column {
+	columns := make([]mysql.Column, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		if column.AutoIncrement() || strings.Contains(column.Extra, "GENERATED") {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// foreignKeys groups the foreign key columns by the constraint
+func foreignKeys(table mysql.Table) []foreignKey {
+	fkMap := map[string]*foreignKey{}
+	var names []string
+	for _, fk := range table.ForeignKeys {
+		g := fkMap[fk.ConstraintName]
+		if g == nil {
+			g = &foreignKey{referencedTable: fk.ReferencedTableName}
+			fkMap[fk.ConstraintName] = g
+			names = append(names, fk.ConstraintName)
+		}
+		g.columns = append(g.columns, fk.ColumnName)
+		g.referencedColumns = append(g.referencedColumns, fk.ReferencedColumnName)
+	}
+	sort.Strings(names)
+	res := make([]foreignKey, len(names))
+	for i, name := range names {
+		res[i] = *fkMap[name]
+	}
+	return res
+}
+
+// uniqueIndexes returns the positions of the columns of each unique index in the row
+func uniqueIndexes(table mysql.Table, columnIndex map[string]int) [][]int {
+	var res [][]int
+	var current []int
+	var name string
+	valid := false
+	flush := func() {
+		if valid && len(current) > 0 {
+			res = append(res, current)
+		}
+	}
+	for _, index := range table.Indexes {
+		if index.IndexName != name {
+			flush()
+			name = index.IndexName
+			current = nil
+			valid = index.NonUnique == 0
+		}
+		i, ok := columnIndex[index.ColumnName]
+		if !ok {
+			valid = false // the auto increment column is always unique
+			continue
+		}
+		current = append(current, i)
+	}
+	flush()
+	return res
+}
+
+// markUnique marks the values of the unique indexes as seen, and returns false if they are duplicated
+func markUnique(row []interface{}, uniques [][]int, seen []map[string]bool) bool {
+	keys := make([]string, len(uniques))
+	for i, unique := range uniques {
+		values := make([]interface{}, len(unique))
+		for j, idx := range unique {
+			values[j] = row[idx]
+		}
+		key, ok := formatKey(values)
+		if ok && seen[i][key] {
+			return false
+		}
+		keys[i] = key
+	}
+	for i, key := range keys {
+		if key != "" {
+			seen[i][key] = true
+		}
+	}
+	return true
+}
+
+func quote(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+func quoteJoin(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quote(name)
+	}
+	return strings.Join(quoted, ",")
+}
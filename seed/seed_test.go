@@ -0,0 +1,54 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+func TestSeed_SortTables(t *testing.T) {
+	assert := assert.New(t)
+	fk := func(table, column, refTable string) mysql.ForeignKey {
+		return mysql.ForeignKey{ConstraintName: "fk_" + table + "_" + column, TableName: table, ColumnName: column, ReferencedTableName: refTable, ReferencedColumnName: "id"}
+	}
+	tables := []mysql.Table{
+		{Name: "user_items", ForeignKeys: []mysql.ForeignKey{fk("user_items", "user_id", "users"), fk("user_items", "item_id", "items")}},
+		{Name: "items", ForeignKeys: []mysql.ForeignKey{fk("items", "parent_id", "items")}}, // self reference
+		{Name: "users", ForeignKeys: []mysql.ForeignKey{fk("users", "group_id", "groups")}}, // groups is not a target
+		{Name: "a", ForeignKeys: []mysql.ForeignKey{fk("a", "b_id", "b")}},
+		{Name: "b", ForeignKeys: []mysql.ForeignKey{fk("b", "a_id", "a")}}, // cycle
+	}
+	names := func(tables []mysql.Table) []string {
+		res := make([]string, len(tables))
+		for i, table := range tables {
+			res[i] = table.Name
+		}
+		return res
+	}
+	assert.Equal([]string{"items", "users", "user_items", "a", "b"}, names(SortTables(tables)))
+}
+
+func TestSeed_uniqueIndexes(t *testing.T) {
+	assert := assert.New(t)
+	table := mysql.Table{
+		Indexes: []mysql.Index{
+			{IndexName: "PRIMARY", ColumnName: "id"},
+			{IndexName: "uniq_email", ColumnName: "email"},
+			{IndexName: "uniq_user_item", ColumnName: "user_id"},
+			{IndexName: "uniq_user_item", ColumnName: "item_id", SeqInIndex: 2},
+			{IndexName: "idx_status", ColumnName: "status", NonUnique: 1},
+		},
+	}
+	columnIndex := map[string]int{"email": 0, "user_id": 1, "item_id": 2, "status": 3} // id is auto increment
+	uniques := uniqueIndexes(table, columnIndex)
+	assert.Equal([][]int{{0}, {1, 2}}, uniques)
+
+	seen := []map[string]bool{{}, {}}
+	assert.True(markUnique([]interface{}{"a@example.com", int64(1), int64(1), "x"}, uniques, seen))
+	assert.False(markUnique([]interface{}{"A@example.com", int64(1), int64(2), "x"}, uniques, seen)) // case insensitive
+	assert.False(markUnique([]interface{}{"b@example.com", int64(1), int64(1), "x"}, uniques, seen))
+	assert.True(markUnique([]interface{}{"b@example.com", int64(1), int64(2), "x"}, uniques, seen))
+	assert.True(markUnique([]interface{}{nil, int64(1), nil, "x"}, uniques, seen))
+	assert.True(markUnique([]interface{}{nil, int64(1), nil, "x"}, uniques, seen)) // NULL is never duplicated
+}
@@ -0,0 +1,137 @@
+package seed
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suzujun/gendao/dummy"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+// nullRate is the rate of NULL in the nullable columns
+const nullRate = 0.1
+
+// Value returns a random value of the column, which fits in the column type
+func Value(r *rand.Rand, column mysql.Column) interface{} {
+	if column.IsNullable && r.Float64() < nullRate {
+		return nil
+	}
+	if values := column.EnumValues(); len(values) > 0 {
+		if column.DataType == "set" {
+			return dummy.Set(r, values...)
+		}
+		return dummy.Enum(r, values...)
+	}
+	switch column.DataType {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint":
+		if column.DataType == "tinyint" && strings.HasPrefix(column.ColumnType, "tinyint(1)") {
+			return r.Intn(2) // bool
+		}
+		rng := column.DataTypeRange()
+		if column.Unsigned() {
+			return dummy.Uint64(r, uint64(rng.Min), rng.Max)
+		}
+		return dummy.Int64(r, rng.Min, int64(rng.Max))
+	case "bit":
+		bits := uintValue(column.NumericPrecision, 1)
+		if bits >= 64 {
+			return r.Uint64()
+		}
+		return dummy.Uint64(r, 0, 1<<uint(bits)-1)
+	case "float", "double", "real":
+		return dummy.Float64(r, -1000, 1000)
+	case "decimal", "dec", "numeric":
+		return randDecimal(r, uintValue(column.NumericPrecision, 10), uintValue(column.NumericScale, 0))
+	case "char", "varchar":
+		max := uintValue(column.CharacterMaximumLength, 1)
+		return dummy.String(r, (max+2)/3, max)
+	case "tinytext", "text", "mediumtext", "longtext":
+		return dummy.String(r, 1, uintValue(column.CharacterMaximumLength, dummy.MaxLength))
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		max := uintValue(column.CharacterMaximumLength, dummy.MaxLength)
+		if column.DataType == "binary" {
+			return dummy.Bytes(r, max, max) // binary is fixed length
+		}
+		return dummy.Bytes(r, 1, max)
+	case "date":
+		return dummy.Date(r).Format("2006-01-02")
+	case "datetime", "timestamp":
+		return dummy.Time(r, uintValue(column.DatetimePrecision, 0))
+	case "time":
+		return dummy.Time(r, 0).Format("15:04:05")
+	case "year":
+		return dummy.Time(r, 0).Year()
+	case "json":
+		return `{"value": "` + dummy.String(r, 10, 10) + `"}`
+	default:
+		return dummy.String(r, 10, 10)
+	}
+}
+
+func uintValue(p *uint, def int) int {
+	if p == nil {
+		return def
+	}
+	return int(*p)
+}
+
+// randDecimal returns a random decimal as string, which has the digits within precision and scale.
+// It is exact unlike dummy.Decimal, which is float64.
+func randDecimal(r *rand.Rand, precision, scale int) string {
+	digits := func(n int) string {
+		if n <= 0 {
+			return ""
+		}
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = byte('0' + r.Intn(10))
+		}
+		return string(b)
+	}
+	integer := strings.TrimLeft(digits(r.Intn(precision-scale+1)), "0")
+	if integer == "" {
+		integer = "0"
+	}
+	if scale == 0 {
+		return integer
+	}
+	return integer + "." + digits(scale)
+}
+
+// formatKey returns the key of the values for the uniqueness check,
+// and false if the values contain NULL which is never duplicated in the unique index
+func formatKey(values []interface{}) (string, bool) {
+	keys := make([]string, len(values))
+	for i, v := range values {
+		switch value := v.(type) {
+		case nil:
+			return "", false
+		case []byte:
+			keys[i] = string(value)
+		case time.Time:
+			keys[i] = value.Format(time.RFC3339Nano)
+		case int64:
+			keys[i] = strconv.FormatInt(value, 10)
+		case uint64:
+			keys[i] = strconv.FormatUint(value, 10)
+		default:
+			keys[i] = strings.TrimSpace(strings.ToLower(toString(value)))
+		}
+	}
+	return strings.Join(keys, "\x00"), true
+}
+
+func toString(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
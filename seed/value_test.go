@@ -0,0 +1,105 @@
+package seed
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suzujun/gendao/helper/mysql"
+)
+
+func TestSeed_Value(t *testing.T) {
+	uintPtr := func(v uint) *uint { return &v }
+	tests := []struct {
+		title  string
+		column mysql.Column
+		check  func(assert *assert.Assertions, v interface{})
+	}{
+		{
+			title:  "tinyint unsigned",
+			column: mysql.Column{DataType: "tinyint", ColumnType: "tinyint(3) unsigned"},
+			check: func(assert *assert.Assertions, v interface{}) {
+				assert.True(v.(uint64) <= 255, v)
+			},
+		},
+		{
+			title:  "smallint signed",
+			column: mysql.Column{DataType: "smallint", ColumnType: "smallint(6)"},
+			check: func(assert *assert.Assertions, v interface{}) {
+				assert.True(v.(int64) >= -32768 && v.(int64) <= 32767, v)
+			},
+		},
+		{
+			title:  "bigint unsigned",
+			column: mysql.Column{DataType: "bigint", ColumnType: "bigint(20) unsigned"},
+			check: func(assert *assert.Assertions, v interface{}) {
+				assert.IsType(uint64(0), v)
+			},
+		},
+		{
+			title:  "varchar",
+			column: mysql.Column{DataType: "varchar", ColumnType: "varchar(12)", CharacterMaximumLength: uintPtr(12)},
+			check: func(assert *assert.Assertions, v interface{}) {
+				assert.True(len(v.(string)) >= 4 && len(v.(string)) <= 12, v)
+			},
+		},
+		{
+			title:  "enum",
+			column: mysql.Column{DataType: "enum", ColumnType: "enum('active','it''s')"},
+			check: func(assert *assert.Assertions, v interface{}) {
+				assert.Contains([]string{"active", "it's"}, v)
+			},
+		},
+		{
+			title:  "set",
+			column: mysql.Column{DataType: "set", ColumnType: "set('a','b')"},
+			check: func(assert *assert.Assertions, v interface{}) {
+				assert.Contains([]string{"", "a", "b", "a,b"}, v)
+			},
+		},
+		{
+			title:  "decimal",
+			column: mysql.Column{DataType: "decimal", ColumnType: "decimal(5,2)", NumericPrecision: uintPtr(5), NumericScale: uintPtr(2)},
+			check: func(assert *assert.Assertions, v interface{}) {
+				parts := strings.Split(v.(string), ".")
+				if assert.Len(parts, 2, v) {
+					assert.True(len(parts[0]) >= 1 && len(parts[0]) <= 3, v)
+					assert.Len(parts[1], 2, v)
+				}
+			},
+		},
+		{
+			title:  "datetime",
+			column: mysql.Column{DataType: "datetime", ColumnType: "datetime"},
+			check: func(assert *assert.Assertions, v interface{}) {
+				tm := v.(time.Time)
+				assert.Equal(0, tm.Nanosecond())
+				assert.True(tm.Year() >= 2000 && tm.Year() < 2030, v)
+			},
+		},
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			assert := assert.New(t)
+			for i := 0; i < 100; i++ {
+				test.check(assert, Value(r, test.column))
+			}
+		})
+	}
+}
+
+func TestSeed_Value_nullable(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(1))
+	column := mysql.Column{DataType: "int", ColumnType: "int(11)", IsNullable: true}
+	nulls := 0
+	for i := 0; i < 1000; i++ {
+		if Value(r, column) == nil {
+			nulls++
+		}
+	}
+	assert.True(nulls > 0 && nulls < 500, nulls)
+}
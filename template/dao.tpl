@@ -0,0 +1,301 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+package dao
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"gopkg.in/gorp.v1"
+)
+
+var (
+	// MaxAllowedPacket is max_allowed_packet of the server, multi-row statements are split to fit in it
+	MaxAllowedPacket = 4 << 20
+	// MaxPlaceholders is the max number of placeholders in a prepared statement
+	MaxPlaceholders = 65535
+)
+
+// ErrConflict is returned when the row was updated by another process since it was read
+var ErrConflict = errors.New("conflict with the version column")
+
+type (
+	// Executor runs queries for a dao, satisfied by both *gorp.DbMap and *gorp.Transaction
+	Executor interface {
+		gorp.SqlExecutor
+	}
+	// Daos holds the dao of every table
+	Daos struct { {{range .Tables}}
+		{{.NameByPascalcase}} {{.NameByPascalcase}}{{end}}
+		dbm *gorp.DbMap
+	}
+	baseDao struct {
+		dbm         Executor
+		dbs         Executor
+		tableName   string
+		columnsName string
+	}
+)
+
+// NewDaos generate the dao of every table
+func NewDaos(dbm, dbs *gorp.DbMap) Daos {
+	return Daos{ {{range .Tables}}
+		{{.NameByPascalcase}}: New{{.NameByPascalcase}}(dbm, dbs),{{end}}
+		dbm: dbm,
+	}
+}
+
+// WithExecutor returns daos which run every query on exec
+func (d Daos) WithExecutor(exec Executor) Daos {
+	return Daos{ {{range .Tables}}
+		{{.NameByPascalcase}}: d.{{.NameByPascalcase}}.WithExecutor(exec),{{end}}
+		dbm: d.dbm,
+	}
+}
+
+// RunInTx runs fn with every dao bound to the same transaction.
+// The transaction is rolled back when fn returns an error or panics, and committed otherwise.
+func (d Daos) RunInTx(ctx context.Context, fn func(tx Daos) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tx, err := d.dbm.Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction failed")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(d.WithExecutor(tx)); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return errors.Wrapf(err, "rollback failed [%s]", rerr)
+		}
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "commit failed")
+}
+
+func newBaseDao(dbm, dbs Executor) baseDao {
+	return baseDao{dbm: dbm, dbs: dbs}
+}
+
+func (dao baseDao) withExecutor(exec Executor) baseDao {
+	dao.dbm = exec
+	dao.dbs = exec
+	return dao
+}
+
+func (dao baseDao) newSelectBuilder() sq.SelectBuilder {
+	return sq.Select(dao.columnsName).From(dao.tableName)
+}
+
+func (dao baseDao) preInsert(m interface{}) error {
+	if hook, ok := m.(gorp.HasPreInsert); ok {
+		return hook.PreInsert(dao.dbm)
+	}
+	return nil
+}
+
+func (dao baseDao) preUpdate(m interface{}) error {
+	if hook, ok := m.(gorp.HasPreUpdate); ok {
+		return hook.PreUpdate(dao.dbm)
+	}
+	return nil
+}
+
+// updateColumns updates the columns of the row by UPDATE ... SET
+func (dao baseDao) updateColumns(sets map[string]interface{}, where sq.Sqlizer) (int64, error) {
+	query, args, err := sq.Update(dao.tableName).SetMap(sets).Where(where).ToSql()
+	if err != nil {
+		return 0, errors.Wrapf(err, "build sql failed [sql='%s'][args='%+v']", query, args)
+	}
+	res, err := dao.dbm.Exec(query, args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "exec failed [sql='%s'][args='%+v']", query, args)
+	}
+	return res.RowsAffected()
+}
+
+// deleteWhere deletes the rows by DELETE ... WHERE
+func (dao baseDao) deleteWhere(where sq.Eq) (int64, error) {
+	query, args, err := sq.Delete(dao.tableName).Where(where).ToSql()
+	if err != nil {
+		return 0, errors.Wrapf(err, "build sql failed [sql='%s'][args='%+v']", query, args)
+	}
+	res, err := dao.dbm.Exec(query, args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "exec failed [sql='%s'][args='%+v']", query, args)
+	}
+	return res.RowsAffected()
+}
+
+// insertMany inserts rows by multi-row INSERT statements split into chunks
+func (dao baseDao) insertMany(columns []string, rows [][]interface{}) error {
+	head := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", dao.tableName, strings.Join(columns, ","))
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+	var query bytes.Buffer
+	var args []interface{}
+	var size int
+	flush := func() error {
+		if len(args) == 0 {
+			return nil
+		}
+		stmt := query.String()
+		if _, err := dao.dbm.Exec(stmt, args...); err != nil {
+			return errors.Wrapf(err, "exec failed [sql='%.100s...'][rows=%d]", stmt, len(args)/len(columns))
+		}
+		query.Reset()
+		args = args[:0]
+		return nil
+	}
+	for _, row := range rows {
+		rowSize := len(placeholder) + 1
+		for _, v := range row {
+			rowSize += argSize(v)
+		}
+		if len(args) > 0 && (size+rowSize > MaxAllowedPacket || len(args)+len(row) > MaxPlaceholders) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if len(args) == 0 {
+			query.WriteString(head)
+			size = len(head)
+		} else {
+			query.WriteByte(',')
+		}
+		query.WriteString(placeholder)
+		args = append(args, row...)
+		size += rowSize
+	}
+	return flush()
+}
+
+// execUpsert inserts the row, or updates the columns of the row when a key is duplicated.
+// The auto increment column is set by LAST_INSERT_ID, so that LastInsertId returns the id of the updated row as well.
+// The version column is incremented when the row is updated regardless of the version of values,
+// and LastInsertId returns the incremented version instead if the table has no auto increment column.
+func (dao baseDao) execUpsert(columns []string, values []interface{}, updateColumns []string, autoIncrementColumn, versionColumn string) (sql.Result, error) {
+	sets := make([]string, 0, len(updateColumns)+2)
+	for _, column := range updateColumns {
+		sets = append(sets, fmt.Sprintf("%s = VALUES(%s)", column, column))
+	}
+	if versionColumn != "" && autoIncrementColumn == "" {
+		sets = append(sets, fmt.Sprintf("%s = LAST_INSERT_ID(%s + 1)", versionColumn, versionColumn))
+	} else if versionColumn != "" {
+		sets = append(sets, fmt.Sprintf("%s = %s + 1", versionColumn, versionColumn))
+	}
+	if autoIncrementColumn != "" {
+		sets = append(sets, fmt.Sprintf("%s = LAST_INSERT_ID(%s)", autoIncrementColumn, autoIncrementColumn))
+	} else if len(sets) == 0 {
+		sets = append(sets, fmt.Sprintf("%s = %s", columns[0], columns[0])) // nothing to update
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
+		dao.tableName, strings.Join(columns, ","), placeholders, strings.Join(sets, ", "))
+	res, err := dao.dbm.Exec(query, values...)
+	return res, errors.Wrapf(err, "exec failed [sql='%s']", query)
+}
+
+// argSize returns the approximate size of the value sent to the server
+func argSize(v interface{}) int {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if value, err := valuer.Value(); err == nil {
+			v = value
+		}
+	}
+	const header = 9 // length-encoded integer
+	switch value := v.(type) {
+	case string:
+		return header + len(value)
+	case []byte:
+		return header + len(value)
+	default:
+		return header + 8
+	}
+}
+
+// selectVersion returns the version of the row from the master
+func (dao baseDao) selectVersion(versionColumn string, where sq.Eq) (int64, error) {
+	query, args, err := sq.Select(versionColumn).From(dao.tableName).Where(where).ToSql()
+	if err != nil {
+		return 0, errors.Wrapf(err, "build sql failed [sql='%s'][args='%+v']", query, args)
+	}
+	version, err := dao.dbm.SelectInt(query, args...)
+	return version, errors.Wrapf(err, "select version failed [sql='%s'][args='%+v']", query, args)
+}
+
+func (dao baseDao) newCountBuilder() sq.SelectBuilder {
+	return sq.Select("COUNT(*)").From(dao.tableName)
+}
+
+func (dao baseDao) newExistsBuilder() sq.SelectBuilder {
+	return sq.Select("1").From(dao.tableName).Limit(1)
+}
+
+func (dao baseDao) countByBuilder(builder *sq.SelectBuilder) (int64, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, errors.Wrapf(err, "build sql failed [sql='%s'][args='%+v']", query, args)
+	}
+	count, err := dao.dbs.SelectInt(query, args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "fetch data failed [sql='%s'][args='%+v']", query, args)
+	}
+	return count, nil
+}
+
+func (dao baseDao) existsByBuilder(builder *sq.SelectBuilder) (bool, error) {
+	count, err := dao.countByBuilder(builder) // no rows is counted as 0
+	return count > 0, err
+}
+
+// encodeCursor encodes the values of the last row into an opaque cursor
+func encodeCursor(values ...interface{}) (string, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", errors.Wrapf(err, "encode cursor failed [%+v]", values)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor decodes the cursor created by encodeCursor into dests
+func decodeCursor(cursor string, dests ...interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return errors.Wrapf(err, "invalid cursor [%s]", cursor)
+	}
+	var values []json.RawMessage
+	if err := json.Unmarshal(b, &values); err != nil {
+		return errors.Wrapf(err, "invalid cursor [%s]", cursor)
+	}
+	if len(values) != len(dests) {
+		return errors.Errorf("invalid cursor [%s]", cursor)
+	}
+	for i, value := range values {
+		if err := json.Unmarshal(value, dests[i]); err != nil {
+			return errors.Wrapf(err, "invalid cursor [%s]", cursor)
+		}
+	}
+	return nil
+}
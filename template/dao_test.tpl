@@ -0,0 +1,47 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+package dao
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"gopkg.in/gorp.v1"
+)
+
+// testDSNEnv is the environment variable of the DSN of the test database
+const testDSNEnv = "GENDAO_TEST_DSN"
+
+// newTestDaos returns the daos connected to the test database without the foreign key checks.
+// The test is skipped if the DSN is not set.
+func newTestDaos(t *testing.T) Daos {
+	t.Helper()
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+	config, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("invalid %s: %s", testDSNEnv, err)
+	}
+	config.ParseTime = true
+	// the dummy rows refer to the parent rows which are not inserted
+	if config.Params == nil {
+		config.Params = map[string]string{}
+	}
+	config.Params["foreign_key_checks"] = "0"
+	db, err := sql.Open("mysql", config.FormatDSN())
+	if err != nil {
+		t.Fatalf("open database failed: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	dbm := &gorp.DbMap{Db: db, Dialect: gorp.MySQLDialect{Engine: "InnoDB", Encoding: "utf8mb4"}}
+	return NewDaos(dbm, dbm)
+}
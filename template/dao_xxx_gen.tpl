@@ -8,24 +8,40 @@
 package dao
 
 import (
-	"strings"
+	"strings"{{if or .Table.CustomMethodUseTime (and .Table.SoftDeleteColumn .Table.PrimaryKey.Columns)}}
+	"time"{{end}}
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
-	"gopkg.in/gorp.v1"{{range .Table.CustomMethodUseTypes}}{{if eq . "null.Time"}}
-	"gopkg.in/guregu/null.v3"{{end}}{{end}}
+	"gopkg.in/gorp.v1"{{if .Table.CustomMethodUseNull}}
+	"gopkg.in/guregu/null.v3"{{end}}
 
 	{{if .Table.CustomMethodUseRanger}}"{{ .Config.PackageRoot }}/ranger"
 	{{end}}"{{ .Config.PackageRoot }}/model"
 )
 {{$TableNamePascal := .Table.NameByPascalcase}}
 {{$TableNameCamel := .Table.NameByCamelcase}}
+{{$SoftDelete := .Table.SoftDeleteColumn}}
 type (
 	inner{{ $TableNamePascal }} interface { {{range .Table.CustomMethods}}
 		{{template "part_method_name.tpl" .}}{{end}}
 		Insert({{ $TableNameCamel }} *model.{{ $TableNamePascal }}) error
+		InsertMany({{ $TableNameCamel }}s []*model.{{ $TableNamePascal }}) error{{if or .Table.PrimaryKey.Columns .Table.UniqueIndexes}}
+		Upsert({{ $TableNameCamel }} *model.{{ $TableNamePascal }}) error{{end}}{{range .Table.UniqueIndexes}}
+		UpsertBy{{.ColumnsNameByPascalcase}}({{ $TableNameCamel }} *model.{{ $TableNamePascal }}) (bool, error){{end}}
+{{if .Table.PrimaryKey.Columns}}
+		FindByPKs(pks []model.{{ $TableNamePascal }}PK) (model.{{ $TableNamePascal }}Slice, error)
 		Update({{ $TableNameCamel }} *model.{{ $TableNamePascal }}) error
-		DeleteBy{{range .Table.PrimaryKey.Columns}}{{.NameByPascalcase}}{{end}}({{range .Table.PrimaryKey.Columns}}{{print .NameByCamelcase " " .Type}}{{end}}) error
+		UpdateColumns({{ $TableNameCamel }} *model.{{ $TableNamePascal }}, columns ...model.{{ $TableNamePascal }}Column) error
+		UpdateChanged({{ $TableNameCamel }} *model.{{ $TableNamePascal }}) error
+		DeleteBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}}({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByCamelcase}} {{.Type}}{{end}}) error{{if $SoftDelete}}
+		RestoreBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}}({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByCamelcase}} {{.Type}}{{end}}) error
+		HardDeleteBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}}({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByCamelcase}} {{.Type}}{{end}}) error{{end}}{{end}}
+		WithExecutor(exec Executor) {{ $TableNamePascal }}
+	}
+	// {{ $TableNamePascal }}Generated is the generated methods of {{ $TableNamePascal }}, which are implemented by daomock.{{ $TableNamePascal }}
+	{{ $TableNamePascal }}Generated interface {
+		inner{{ $TableNamePascal }}
 	}
 	// {{ $TableNamePascal }}Dao {{ $TableNameCamel }} dao struct
 	{{ $TableNamePascal }}Dao struct {
@@ -37,8 +53,8 @@ func new{{$TableNamePascal}}(dbm, dbs *gorp.DbMap) *{{$TableNamePascal}}Dao {
 	m := model.{{$TableNamePascal}}{}
 	tableName := m.TableName()
 	pks := m.PrimaryKeys()
-	dbm.AddTableWithName(m, tableName).SetKeys({{.Table.PrimaryKey.AutoIncrement}}, pks...)
-	dbs.AddTableWithName(m, tableName).SetKeys({{.Table.PrimaryKey.AutoIncrement}}, pks...)
+	dbm.AddTableWithName(m, tableName).SetKeys({{.Table.PrimaryKey.AutoIncrement}}, pks...){{with .Table.VersionColumn}}.SetVersionCol("{{.NameByPascalcase}}"){{end}}
+	dbs.AddTableWithName(m, tableName).SetKeys({{.Table.PrimaryKey.AutoIncrement}}, pks...){{with .Table.VersionColumn}}.SetVersionCol("{{.NameByPascalcase}}"){{end}}
 	dao := {{$TableNamePascal}}Dao{}
 	dao.baseDao = newBaseDao(dbm, dbs)
 	dao.tableName = tableName
@@ -50,38 +66,188 @@ func new{{$TableNamePascal}}(dbm, dbs *gorp.DbMap) *{{$TableNamePascal}}Dao {
 // Global Methods for interface
 // ------------------------------
 
-{{range .Table.CustomMethods}}
-// {{.Name}} get {{$TableNameCamel}} with {{range $i, $p := .Params}}{{if ne $i 0}} and {{end}}{{.NameByCamelcase}}{{end}}
+{{range .Table.CustomMethods}}{{if .Cursors}}
+// {{.Name}} get {{$TableNameCamel}} with {{range $i, $p := .Params}}{{if .Where}}{{if ne $i 0}} and {{end}}{{.NameByCamelcase}}{{end}}{{end}} {{if .Desc}}before{{else}}after{{end}} the cursor.
+// It returns the cursor of the next page, which is empty on the last page.{{if .IncludingDeleted}}
+// The deleted {{$TableNameCamel}} are included.{{end}}
 func (dao {{ $TableNamePascal }}Dao) {{template "part_method_name.tpl" .}} {
-	builder := dao.newSelectBuilder(){{range .Params}}{{if .Where}}.
+	builder := dao.newSelectBuilder(){{if and $SoftDelete (not .IncludingDeleted)}}.
+		Where(sq.Eq{"{{$SoftDelete.Name}}": nil}){{end}}{{range .Params}}{{if .Where}}.
+		Where(sq.Eq{"{{.Name}}": {{.NameByCamelcase}}}){{end}}{{end}}.{{$desc := .Desc}}
+		OrderBy({{range $i, $p := .Orders}}{{if ne $i 0}}, {{end}}"{{.Name}}{{if $desc}} desc{{end}}"{{end}}).
+		Limit(limit)
+	if cursor != "" { {{range .Cursors}}
+		var cursor{{.NameByPascalcase}} {{.Type}}{{end}}
+		if err := decodeCursor(cursor{{range .Cursors}}, &cursor{{.NameByPascalcase}}{{end}}); err != nil {
+			return nil, "", err
+		}
+		builder = builder.Where("({{range $i, $p := .Cursors}}{{if ne $i 0}}, {{end}}{{.Name}}{{end}}) {{if .Desc}}<{{else}}>{{end}} ({{range $i, $p := .Cursors}}{{if ne $i 0}}, {{end}}?{{end}})"{{range .Cursors}}, cursor{{.NameByPascalcase}}{{end}})
+	}
+	{{$TableNameCamel}}s, err := dao.findManyByBuilder(&builder)
+	if err != nil || len({{$TableNameCamel}}s) == 0 || uint64(len({{$TableNameCamel}}s)) < limit {
+		return {{$TableNameCamel}}s, "", err
+	}
+	last := {{$TableNameCamel}}s[len({{$TableNameCamel}}s)-1]
+	next, err := encodeCursor({{range $i, $p := .Cursors}}{{if ne $i 0}}, {{end}}last.{{.NameByPascalcase}}{{end}})
+	return {{$TableNameCamel}}s, next, err
+}
+{{else}}
+// {{.Name}} {{if .Count}}count{{else if .Exists}}check existence of{{else}}get{{end}} {{$TableNameCamel}} with {{range $i, $p := .Params}}{{if ne $i 0}} and {{end}}{{.NameByCamelcase}}{{end}}{{if .IncludingDeleted}} including the deleted{{end}}
+func (dao {{ $TableNamePascal }}Dao) {{template "part_method_name.tpl" .}} {
+	builder := dao.{{if .Count}}newCountBuilder{{else if .Exists}}newExistsBuilder{{else}}newSelectBuilder{{end}}(){{if and $SoftDelete (not .IncludingDeleted)}}.
+		Where(sq.Eq{"{{$SoftDelete.Name}}": nil}){{end}}{{range .Params}}{{if .Where}}.
 		Where(sq.Eq{"{{.Name}}": {{.NameByCamelcase}}}){{end}}{{end}}{{if .Orders}}.{{$desc := .Desc}}
 		OrderBy({{range $i, $p := .Orders}}{{if ne $i 0}}, {{end}}"{{.Name}}{{if $desc}} desc{{end}}"{{end}}){{end}}{{range .Params}}{{if eq .Name "limit"}}.
 		Limit(limit){{end}}{{end}}{{if .RangeParam}}{{if contains .RangeParam.Type "int"}}
 	builder = ranger.SetWhereInt(builder, "{{.RangeParam.Name}}", {{.RangeParam.NameByCamelcase}}RangeFncs){{else if contains .RangeParam.Type "string"}}
 	builder = ranger.SetWhereStr(builder, "{{.RangeParam.Name}}", {{.RangeParam.NameByCamelcase}}RangeFncs){{else if contains .RangeParam.Type "time"}}
-	builder = ranger.SetWhereTime(builder, "{{.RangeParam.Name}}", {{.RangeParam.NameByCamelcase}}RangeFncs){{end}}{{end}}{{if .ReturnMany}}
+	builder = ranger.SetWhereTime(builder, "{{.RangeParam.Name}}", {{.RangeParam.NameByCamelcase}}RangeFncs){{end}}{{end}}{{if .Count}}
+	return dao.countByBuilder(&builder){{else if .Exists}}
+	return dao.existsByBuilder(&builder){{else if .ReturnMany}}
 	return dao.findManyByBuilder(&builder){{else}}
 	return dao.findOneByBuilder(&builder){{end}}
 }
-{{end}}
+{{end}}{{end}}
 
 // Insert insert {{$TableNameCamel}}
 func (dao {{ $TableNamePascal }}Dao) Insert({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
 	return dao.insert({{$TableNameCamel}})
 }
 
+// InsertMany insert {{$TableNameCamel}}s by multi-row statements
+func (dao {{ $TableNamePascal }}Dao) InsertMany({{$TableNameCamel}}s []*model.{{$TableNamePascal}}) error {
+	columns := []string{ {{range $i, $c := .Table.InsertColumns}}{{if ne $i 0}}, {{end}}"{{.Name}}"{{end}} }
+	rows := make([][]interface{}, len({{$TableNameCamel}}s))
+	for i, m := range {{$TableNameCamel}}s {
+		if err := dao.preInsert(m); err != nil {
+			return errors.Wrapf(err, "pre insert failed [%+v]", m)
+		}{{with $.Table.VersionColumn}}
+		if m.{{.NameByPascalcase}} == 0 {
+			m.{{.NameByPascalcase}} = 1
+		}{{end}}
+		rows[i] = []interface{}{ {{range $i, $c := .Table.InsertColumns}}{{if ne $i 0}}, {{end}}m.{{.NameByPascalcase}}{{end}} }
+	}
+	return errors.Wrapf(dao.insertMany(columns, rows), "insert many failed [count=%d]", len({{$TableNameCamel}}s))
+}
+
+{{if or .Table.PrimaryKey.Columns .Table.UniqueIndexes}}
+// Upsert insert {{$TableNameCamel}}, or update it when the primary key or an unique key is duplicated{{if .Table.VersionColumn}}.
+// The version is not checked, and the incremented version is set to {{$TableNameCamel}} when updated.{{end}}
+func (dao {{ $TableNamePascal }}Dao) Upsert({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
+	updateColumns := []string{ {{range $i, $c := .Table.UpsertColumns}}{{if ne $i 0}}, {{end}}"{{.Name}}"{{end}} }
+	_, err := dao.upsert({{$TableNameCamel}}, updateColumns)
+	return err
+}
+{{end}}{{range .Table.UniqueIndexes}}
+// UpsertBy{{.ColumnsNameByPascalcase}} insert {{$TableNameCamel}}, or update it when any unique key including the primary key is duplicated, not only {{range $i, $c := .Columns}}{{if ne $i 0}} and {{end}}{{.Name}}{{end}}.
+// The columns of {{.Name}} are not updated. It returns true when the row was inserted, and false when it was updated.{{if $.Table.VersionColumn}}
+// The version is not checked, and the incremented version is set to {{$TableNameCamel}} when updated.{{end}}
+func (dao {{ $TableNamePascal }}Dao) UpsertBy{{.ColumnsNameByPascalcase}}({{$TableNameCamel}} *model.{{$TableNamePascal}}) (bool, error) {
+	updateColumns := []string{ {{range $i, $c := .UpsertColumns}}{{if ne $i 0}}, {{end}}"{{.Name}}"{{end}} }
+	return dao.upsert({{$TableNameCamel}}, updateColumns)
+}
+{{end}}
+{{if .Table.PrimaryKey.Columns}}
+// FindByPKs get {{$TableNameCamel}} with primary keys
+func (dao {{ $TableNamePascal }}Dao) FindByPKs(pks []model.{{ $TableNamePascal }}PK) (model.{{ $TableNamePascal }}Slice, error) {
+	if len(pks) == 0 {
+		return model.{{ $TableNamePascal }}Slice{}, nil
+	}
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, 0, len(pks)*{{len .Table.PrimaryKey.Columns}})
+	for i, pk := range pks {
+		placeholders[i] = "({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}?{{end}})"
+		args = append(args{{range .Table.PrimaryKey.Columns}}, pk.{{.NameByPascalcase}}{{end}})
+	}
+	builder := dao.newSelectBuilder(){{with $SoftDelete}}.
+		Where(sq.Eq{"{{.Name}}": nil}){{end}}.
+		Where("({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.Name}}{{end}}) IN ("+strings.Join(placeholders, ", ")+")", args...)
+	return dao.findManyByBuilder(&builder)
+}
+
 // Update update {{$TableNameCamel}}
 func (dao {{ $TableNamePascal }}Dao) Update({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
 	return dao.update({{$TableNameCamel}})
 }
 
-// DeleteBy{{range .Table.PrimaryKey.Columns}}{{.NameByPascalcase}}{{end}} delete {{$TableNameCamel}} by {{range .Table.PrimaryKey.Columns}}{{.Name}}{{end}}
-func (dao {{ $TableNamePascal }}Dao) DeleteBy{{range .Table.PrimaryKey.Columns}}{{.NameByPascalcase}}{{end}}({{range .Table.PrimaryKey.Columns}}{{print .NameByCamelcase " " .Type}}{{end}}) error {
-	m := &model.{{$TableNamePascal}}{ {{range .Table.PrimaryKey.Columns}}{{.NameByPascalcase}}{{end}}: {{range .Table.PrimaryKey.Columns}}{{.NameByCamelcase}}{{end}} }
+// UpdateColumns update only the columns of {{$TableNameCamel}}
+func (dao {{ $TableNamePascal }}Dao) UpdateColumns({{$TableNameCamel}} *model.{{$TableNamePascal}}, columns ...model.{{$TableNamePascal}}Column) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	if err := dao.preUpdate({{$TableNameCamel}}); err != nil {
+		return errors.Wrapf(err, "pre update failed [%+v]", {{$TableNameCamel}})
+	}
+	sets := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		sets[string(column)] = {{$TableNameCamel}}.ColumnValue(column)
+	}{{range .Table.PreUpdateColumns}}
+	sets["{{.Name}}"] = {{$TableNameCamel}}.{{.NameByPascalcase}}{{end}}
+	where := sq.Eq{ {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}"{{.Name}}": {{$TableNameCamel}}.{{.NameByPascalcase}}{{end}} }{{with .Table.VersionColumn}}
+	sets["{{.Name}}"] = sq.Expr("{{.Name}} + 1")
+	where["{{.Name}}"] = {{$TableNameCamel}}.{{.NameByPascalcase}}
+	affected, err := dao.updateColumns(sets, where)
+	if err != nil {
+		return errors.Wrapf(err, "update columns failed [%+v]", {{$TableNameCamel}})
+	}
+	if affected == 0 {
+		return errors.Wrapf(ErrConflict, "update columns failed [%+v]", {{$TableNameCamel}})
+	}
+	{{$TableNameCamel}}.{{.NameByPascalcase}}++
+	return nil{{else}}
+	_, err := dao.updateColumns(sets, where)
+	return errors.Wrapf(err, "update columns failed [%+v]", {{$TableNameCamel}}){{end}}
+}
+
+// UpdateChanged update only the columns of {{$TableNameCamel}} changed by the setters
+func (dao {{ $TableNamePascal }}Dao) UpdateChanged({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
+	if err := dao.UpdateColumns({{$TableNameCamel}}, {{$TableNameCamel}}.ChangedColumns()...); err != nil {
+		return err
+	}
+	{{$TableNameCamel}}.ClearChanged()
+	return nil
+}
+
+// DeleteBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}} delete {{$TableNameCamel}} by {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}} and {{end}}{{.Name}}{{end}}
+func (dao {{ $TableNamePascal }}Dao) DeleteBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}}({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByCamelcase}} {{.Type}}{{end}}) error {
+	m := &model.{{$TableNamePascal}}{ {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByPascalcase}}: {{.NameByCamelcase}}{{end}} }
 	return dao.delete(m)
 }
+{{if $SoftDelete}}
+// RestoreBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}} restore the deleted {{$TableNameCamel}} by {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}} and {{end}}{{.Name}}{{end}}
+func (dao {{ $TableNamePascal }}Dao) RestoreBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}}({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByCamelcase}} {{.Type}}{{end}}) error {
+	m := &model.{{$TableNamePascal}}{ {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByPascalcase}}: {{.NameByCamelcase}}{{end}} }
+	return dao.restore(m)
+}
 
-// ------------------
+// HardDeleteBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}} delete {{$TableNameCamel}} by {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}} and {{end}}{{.Name}}{{end}} physically
+func (dao {{ $TableNamePascal }}Dao) HardDeleteBy{{.Table.PrimaryKey.ColumnsNameByPascalcase}}({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByCamelcase}} {{.Type}}{{end}}) error {
+	m := &model.{{$TableNamePascal}}{ {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByPascalcase}}: {{.NameByCamelcase}}{{end}} }
+	return dao.hardDelete(m)
+}
+{{end}}{{end}}
+// WithExecutor returns a copy of the dao which runs every query on exec
+func (dao {{ $TableNamePascal }}Dao) WithExecutor(exec Executor) {{ $TableNamePascal }} {
+	dao.baseDao = dao.baseDao.withExecutor(exec)
+	return &dao
+}
+
+{{if .Table.PrimaryKey.Columns}}// CreateDummy{{ $TableNamePascal }} inserts new dummy {{ $TableNameCamel }} with the options for tests, and deletes it on the cleanup of t
+func CreateDummy{{ $TableNamePascal }}(t model.TB, dao {{ $TableNamePascal }}Generated, opts ...model.{{ $TableNamePascal }}Option) model.{{ $TableNamePascal }} {
+	t.Helper()
+	m := model.NewDummy{{ $TableNamePascal }}{{if .Table.PrimaryKey.AutoIncrement}}WithoutPK{{end}}(opts...)
+	if err := dao.Insert(&m); err != nil {
+		t.Fatalf("create dummy {{ $TableNameCamel }} failed: %+v", err)
+	}
+	t.Cleanup(func() {
+		if err := dao.{{if $SoftDelete}}HardDeleteBy{{else}}DeleteBy{{end}}{{.Table.PrimaryKey.ColumnsNameByPascalcase}}({{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}m.{{.NameByPascalcase}}{{end}}); err != nil {
+			t.Errorf("delete dummy {{ $TableNameCamel }} failed: %+v", err)
+		}
+	})
+	return m
+}
+
+{{end}}// ------------------
 // Private Methods
 // ------------------
 
@@ -113,13 +279,82 @@ func (dao {{$TableNamePascal}}Dao) insert({{$TableNameCamel}} *model.{{$TableNam
 	return errors.Wrapf(dao.dbm.Insert({{$TableNameCamel}}), "insert failed [%+v]", {{$TableNameCamel}})
 }
 
+func (dao {{$TableNamePascal}}Dao) upsert({{$TableNameCamel}} *model.{{$TableNamePascal}}, updateColumns []string) (bool, error) {
+	if err := dao.preInsert({{$TableNameCamel}}); err != nil {
+		return false, errors.Wrapf(err, "pre insert failed [%+v]", {{$TableNameCamel}})
+	}{{with .Table.VersionColumn}}
+	if {{$TableNameCamel}}.{{.NameByPascalcase}} == 0 {
+		{{$TableNameCamel}}.{{.NameByPascalcase}} = 1
+	}{{end}}
+	columns := []string{ {{range $i, $c := .Table.Columns}}{{if ne $i 0}}, {{end}}"{{.Name}}"{{end}} }
+	values := []interface{}{ {{range $i, $c := .Table.Columns}}{{if ne $i 0}}, {{end}}{{$TableNameCamel}}.{{.NameByPascalcase}}{{end}} }
+	res, err := dao.execUpsert(columns, values, updateColumns, "{{with .Table.AutoIncrementColumn}}{{.Name}}{{end}}", "{{with .Table.VersionColumn}}{{.Name}}{{end}}")
+	if err != nil {
+		return false, errors.Wrapf(err, "upsert failed [%+v]", {{$TableNameCamel}})
+	}{{with .Table.AutoIncrementColumn}}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return false, errors.Wrapf(err, "get last insert id failed [%+v]", {{$TableNameCamel}})
+	}
+	{{$TableNameCamel}}.{{.NameByPascalcase}} = {{.Type}}(id){{end}}
+	// affected rows is 1 if the row is inserted, 2 if updated, and 0 if not changed
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrapf(err, "get affected rows failed [%+v]", {{$TableNameCamel}})
+	}{{with .Table.VersionColumn}}
+	if affected == 2 {
+		// the version of the row was incremented regardless of the version of the model{{with $.Table.AutoIncrementColumn}}
+		version, err := dao.selectVersion("{{$.Table.VersionColumn.Name}}", sq.Eq{"{{.Name}}": {{$TableNameCamel}}.{{.NameByPascalcase}}}){{else}}
+		version, err := res.LastInsertId(){{end}}
+		if err != nil {
+			return false, errors.Wrapf(err, "get version failed [%+v]", {{$TableNameCamel}})
+		}
+		{{$TableNameCamel}}.{{.NameByPascalcase}} = {{.Type}}(version)
+	}{{end}}
+	return affected == 1, nil
+}
+
 func (dao {{$TableNamePascal}}Dao) update({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
-	_, err := dao.dbm.Update({{$TableNameCamel}})
+	_, err := dao.dbm.Update({{$TableNameCamel}}){{if .Table.VersionColumn}}
+	if _, ok := err.(gorp.OptimisticLockError); ok {
+		return errors.Wrapf(ErrConflict, "update failed [%+v]", {{$TableNameCamel}})
+	}{{end}}
 	return errors.Wrapf(err, "update failed [%+v]", {{$TableNameCamel}})
 }
 
+{{if $SoftDelete}}
 func (dao {{$TableNamePascal}}Dao) delete({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
-	_, err := dao.dbm.Delete({{$TableNameCamel}})
+	return errors.Wrapf(dao.setDeleted({{$TableNameCamel}}, true), "delete failed [%+v]", {{$TableNameCamel}})
+}
+
+func (dao {{$TableNamePascal}}Dao) restore({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
+	return errors.Wrapf(dao.setDeleted({{$TableNameCamel}}, false), "restore failed [%+v]", {{$TableNameCamel}})
+}
+
+// setDeleted set the current time to {{$SoftDelete.Name}} if deleted, and NULL otherwise.
+// The other columns are not changed except the columns refreshed by preUpdate{{if .Table.VersionColumn}} and the version{{end}}.
+func (dao {{$TableNamePascal}}Dao) setDeleted({{$TableNameCamel}} *model.{{$TableNamePascal}}, deleted bool) error {
+	if err := dao.preUpdate({{$TableNameCamel}}); err != nil {
+		return errors.Wrapf(err, "pre update failed [%+v]", {{$TableNameCamel}})
+	}
+	now := time.Now().Round(time.Second)
+	sets := map[string]interface{}{ {{range .Table.PreUpdateColumns}}"{{.Name}}": {{$TableNameCamel}}.{{.NameByPascalcase}}, {{end}}{{with .Table.VersionColumn}}"{{.Name}}": sq.Expr("{{.Name}} + 1"){{end}} }
+	where := sq.And{sq.Eq{ {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}"{{.Name}}": {{$TableNameCamel}}.{{.NameByPascalcase}}{{end}} }}
+	if deleted {
+		sets["{{$SoftDelete.Name}}"] = now
+		where = append(where, sq.Eq{"{{$SoftDelete.Name}}": nil})
+	} else {
+		sets["{{$SoftDelete.Name}}"] = nil
+		where = append(where, sq.NotEq{"{{$SoftDelete.Name}}": nil})
+	}
+	_, err := dao.updateColumns(sets, where)
+	return err
+}
+{{end}}
+func (dao {{$TableNamePascal}}Dao) {{if $SoftDelete}}hardDelete{{else}}delete{{end}}({{$TableNameCamel}} *model.{{$TableNamePascal}}) error { {{if .Table.VersionColumn}}
+	// deleted regardless of the version, since gorp checks the version on delete as well
+	_, err := dao.deleteWhere(sq.Eq{ {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}"{{.Name}}": {{$TableNameCamel}}.{{.NameByPascalcase}}{{end}} }){{else}}
+	_, err := dao.dbm.Delete({{$TableNameCamel}}){{end}}
 	return errors.Wrapf(err, "delete failed [%+v]", {{$TableNameCamel}})
 }
 
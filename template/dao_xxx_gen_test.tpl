@@ -0,0 +1,88 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}/{{ .Table.Name }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+package dao
+
+{{$useTime := false}}{{$useNull := false}}{{range .Table.CustomMethods}}{{range .Params}}{{if and .FieldName (contains .Type "[]")}}{{if contains .Type "time."}}{{$useTime = true}}{{end}}{{if contains .Type "null."}}{{$useNull = true}}{{end}}{{end}}{{end}}{{end}}
+import (
+	"testing"{{if $useTime}}
+	"time"{{end}}
+{{if $useNull}}
+	"gopkg.in/guregu/null.v3"{{end}}
+	"{{ .Config.PackageRoot }}/model"
+)
+{{$TableNamePascal := .Table.NameByPascalcase}}
+{{$TableNameCamel := .Table.NameByCamelcase}}
+{{$SoftDelete := .Table.SoftDeleteColumn}}
+{{$PKName := .Table.PrimaryKey.ColumnsNameByPascalcase}}
+{{$PKArgs := ""}}{{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}{{$PKArgs = print $PKArgs ", "}}{{end}}{{$PKArgs = print $PKArgs "m." .NameByPascalcase}}{{end}}
+// Test{{ $TableNamePascal }}Dao_roundTrip inserts a dummy {{ $TableNameCamel }}, finds it by every finder, updates and deletes it
+func Test{{ $TableNamePascal }}Dao_roundTrip(t *testing.T) {
+	dao := newTestDaos(t).{{ $TableNamePascal }}
+	model.ReportDummySeed(t)
+	m := model.NewDummy{{ $TableNamePascal }}{{if .Table.PrimaryKey.AutoIncrement}}WithoutPK{{end}}(){{if and .Table.PrimaryKey.Columns (not .Table.PrimaryKey.AutoIncrement)}}
+	// remove the row left by the failed run
+	if err := dao.{{if $SoftDelete}}HardDeleteBy{{else}}DeleteBy{{end}}{{$PKName}}({{$PKArgs}}); err != nil {
+		t.Fatalf("delete failed: %+v", err)
+	}{{end}}
+	if err := dao.Insert(&m); err != nil {
+		t.Fatalf("insert failed: %+v", err)
+	}
+{{range .Table.CustomMethods}}
+	t.Run("{{.Name}}", func(t *testing.T) {
+		{{if .Cursors}}res, _, err{{else}}res, err{{end}} := dao.{{.Name}}({{range $i, $p := .Params}}{{if .FieldName}}{{if ne $i 0}}, {{end}}{{if contains .Type "[]"}}{{.Type}}{m.{{.FieldName}}}{{else}}m.{{.FieldName}}{{end}}{{else if eq .Name "limit"}}{{if ne $i 0}}, {{end}}100{{else if eq .Name "cursor"}}{{if ne $i 0}}, {{end}}""{{end}}{{end}})
+		if err != nil {
+			t.Fatalf("{{.Name}} failed: %+v", err)
+		}{{if .Count}}
+		if res < 1 {
+			t.Errorf("{{.Name}} = %d, want >= 1", res)
+		}{{else if .Exists}}
+		if !res {
+			t.Errorf("{{.Name}} = false, want true")
+		}{{else if not .ReturnMany}}
+		if res == nil{{range $.Table.PrimaryKey.Columns}} || res.{{.NameByPascalcase}} != m.{{.NameByPascalcase}}{{end}} {
+			t.Errorf("{{.Name}} = %+v, want %+v", res, m)
+		}{{else if and (not .Orders) (not $.Table.PrimaryKey.Columns)}}{{/* m can not be identified without the primary key */}}
+		if len(res) == 0 {
+			t.Errorf("{{.Name}} is empty, want %+v", m)
+		}{{else if not .Orders}}{{/* the result with limit may not contain m */}}
+		found := false
+		for _, r := range res {
+			found = found || ({{range $i, $c := $.Table.PrimaryKey.Columns}}{{if ne $i 0}} && {{end}}r.{{.NameByPascalcase}} == m.{{.NameByPascalcase}}{{end}})
+		}
+		if !found {
+			t.Errorf("{{.Name}} does not contain %+v", m)
+		}{{else}}
+		_ = res{{end}}
+	}){{if .Cursors}}
+	t.Run("{{.Name}}WithZeroLimit", func(t *testing.T) {
+		res, next, err := dao.{{.Name}}({{range $i, $p := .Params}}{{if ne $i 0}}, {{end}}{{if .FieldName}}{{if contains .Type "[]"}}{{.Type}}{m.{{.FieldName}}}{{else}}m.{{.FieldName}}{{end}}{{else if eq .Name "limit"}}0{{else}}""{{end}}{{end}})
+		if err != nil {
+			t.Fatalf("{{.Name}} failed: %+v", err)
+		}
+		if len(res) != 0 || next != "" {
+			t.Errorf("{{.Name}} = %+v, %q, want empty", res, next)
+		}
+	}){{end}}
+{{end}}{{if .Table.PrimaryKey.Columns}}
+	if err := dao.Update(&m); err != nil {
+		t.Fatalf("update failed: %+v", err)
+	}
+	if err := dao.DeleteBy{{$PKName}}({{$PKArgs}}); err != nil {
+		t.Fatalf("delete failed: %+v", err)
+	}
+	res, err := dao.FindByPKs([]model.{{ $TableNamePascal }}PK{m.PK()})
+	if err != nil {
+		t.Fatalf("find by pks failed: %+v", err)
+	}
+	if len(res) != 0 {
+		t.Errorf("deleted {{ $TableNameCamel }} is found: %+v", res)
+	}{{if $SoftDelete}}
+	if err := dao.HardDeleteBy{{$PKName}}({{$PKArgs}}); err != nil {
+		t.Fatalf("hard delete failed: %+v", err)
+	}{{end}}{{end}}
+}
@@ -0,0 +1,58 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+package daomock
+
+import (
+	"sync"
+)
+
+type (
+	// Call is a call of the mock method
+	Call struct {
+		Method string
+		Args   []interface{}
+	}
+	// Recorder records the calls of the mock methods
+	Recorder struct {
+		mu    sync.Mutex
+		calls []Call
+	}
+)
+
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the recorded calls in order
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsOf returns the recorded calls of the method in order
+func (r *Recorder) CallsOf(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset clears the recorded calls
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
@@ -0,0 +1,169 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}/{{ .Table.Name }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+package daomock
+
+import ({{if .Table.CustomMethodUseTime}}
+	"time"
+{{end}}{{if .Table.CustomMethodUseNull}}
+	"gopkg.in/guregu/null.v3"{{end}}
+
+	"{{ .Config.PackageRoot }}/dao"
+	"{{ .Config.PackageRoot }}/model"{{if .Table.CustomMethodUseRanger}}
+	"{{ .Config.PackageRoot }}/ranger"{{end}}
+)
+{{$TableNamePascal := .Table.NameByPascalcase}}
+{{$TableNameCamel := .Table.NameByCamelcase}}
+{{$PKParams := ""}}{{$PKArgs := ""}}{{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}{{$PKParams = print $PKParams ", "}}{{$PKArgs = print $PKArgs ", "}}{{end}}{{$PKParams = print $PKParams .NameByCamelcase " " .Type}}{{$PKArgs = print $PKArgs .NameByCamelcase}}{{end}}
+{{$PKName := .Table.PrimaryKey.ColumnsNameByPascalcase}}
+{{$SoftDelete := .Table.SoftDeleteColumn}}
+// {{ $TableNamePascal }} is the mock of dao.{{ $TableNamePascal }}.
+// Each method records the call, and calls {Method}Func if it is set, otherwise returns zero values.
+// The methods added to dao.{{ $TableNamePascal }} by hand are delegated to the embedded dao.{{ $TableNamePascal }}.
+type {{ $TableNamePascal }} struct {
+	dao.{{ $TableNamePascal }}
+	Recorder
+{{range .Table.CustomMethods}}
+	{{.Name}}Func func{{template "part_method_signature" .}}{{end}}
+	InsertFunc     func({{$TableNameCamel}} *model.{{$TableNamePascal}}) error
+	InsertManyFunc func({{$TableNameCamel}}s []*model.{{$TableNamePascal}}) error{{if or .Table.PrimaryKey.Columns .Table.UniqueIndexes}}
+	UpsertFunc     func({{$TableNameCamel}} *model.{{$TableNamePascal}}) error{{end}}{{range .Table.UniqueIndexes}}
+	UpsertBy{{.ColumnsNameByPascalcase}}Func func({{$TableNameCamel}} *model.{{$TableNamePascal}}) (bool, error){{end}}{{if .Table.PrimaryKey.Columns}}
+	FindByPKsFunc     func(pks []model.{{$TableNamePascal}}PK) (model.{{$TableNamePascal}}Slice, error)
+	UpdateFunc        func({{$TableNameCamel}} *model.{{$TableNamePascal}}) error
+	UpdateColumnsFunc func({{$TableNameCamel}} *model.{{$TableNamePascal}}, columns ...model.{{$TableNamePascal}}Column) error
+	UpdateChangedFunc func({{$TableNameCamel}} *model.{{$TableNamePascal}}) error
+	DeleteBy{{$PKName}}Func func({{$PKParams}}) error{{if $SoftDelete}}
+	RestoreBy{{$PKName}}Func func({{$PKParams}}) error
+	HardDeleteBy{{$PKName}}Func func({{$PKParams}}) error{{end}}{{end}}
+}
+
+// New{{ $TableNamePascal }} generate new mock of dao.{{ $TableNamePascal }}
+func New{{ $TableNamePascal }}() *{{ $TableNamePascal }} {
+	return &{{ $TableNamePascal }}{}
+}
+{{range .Table.CustomMethods}}{{$args := ""}}{{range $i, $p := .Params}}{{if ne $i 0}}{{$args = print $args ", "}}{{end}}{{$args = print $args $p.NameByCamelcase}}{{end}}
+// {{.Name}} ...
+func (m *{{ $TableNamePascal }}) {{template "part_method_name.tpl" .}} {
+	m.record("{{.Name}}"{{if $args}}, {{$args}}{{end}})
+	if m.{{.Name}}Func != nil {
+		return m.{{.Name}}Func({{$args}}{{if .RangeParam}}...{{end}})
+	}
+	return {{if .Count}}0{{else if .Exists}}false{{else}}nil{{end}}, {{if .Cursors}}"", {{end}}nil
+}
+{{end}}
+// Insert ...
+func (m *{{ $TableNamePascal }}) Insert({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
+	m.record("Insert", {{$TableNameCamel}})
+	if m.InsertFunc != nil {
+		return m.InsertFunc({{$TableNameCamel}})
+	}
+	return nil
+}
+
+// InsertMany ...
+func (m *{{ $TableNamePascal }}) InsertMany({{$TableNameCamel}}s []*model.{{$TableNamePascal}}) error {
+	m.record("InsertMany", {{$TableNameCamel}}s)
+	if m.InsertManyFunc != nil {
+		return m.InsertManyFunc({{$TableNameCamel}}s)
+	}
+	return nil
+}
+{{if or .Table.PrimaryKey.Columns .Table.UniqueIndexes}}
+// Upsert ...
+func (m *{{ $TableNamePascal }}) Upsert({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
+	m.record("Upsert", {{$TableNameCamel}})
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc({{$TableNameCamel}})
+	}
+	return nil
+}
+{{end}}{{range .Table.UniqueIndexes}}
+// UpsertBy{{.ColumnsNameByPascalcase}} ...
+func (m *{{ $TableNamePascal }}) UpsertBy{{.ColumnsNameByPascalcase}}({{$TableNameCamel}} *model.{{$TableNamePascal}}) (bool, error) {
+	m.record("UpsertBy{{.ColumnsNameByPascalcase}}", {{$TableNameCamel}})
+	if m.UpsertBy{{.ColumnsNameByPascalcase}}Func != nil {
+		return m.UpsertBy{{.ColumnsNameByPascalcase}}Func({{$TableNameCamel}})
+	}
+	return false, nil
+}
+{{end}}{{if .Table.PrimaryKey.Columns}}
+// FindByPKs ...
+func (m *{{ $TableNamePascal }}) FindByPKs(pks []model.{{$TableNamePascal}}PK) (model.{{$TableNamePascal}}Slice, error) {
+	m.record("FindByPKs", pks)
+	if m.FindByPKsFunc != nil {
+		return m.FindByPKsFunc(pks)
+	}
+	return nil, nil
+}
+
+// Update ...
+func (m *{{ $TableNamePascal }}) Update({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
+	m.record("Update", {{$TableNameCamel}})
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc({{$TableNameCamel}})
+	}
+	return nil
+}
+
+// UpdateColumns ...
+func (m *{{ $TableNamePascal }}) UpdateColumns({{$TableNameCamel}} *model.{{$TableNamePascal}}, columns ...model.{{$TableNamePascal}}Column) error {
+	m.record("UpdateColumns", {{$TableNameCamel}}, columns)
+	if m.UpdateColumnsFunc != nil {
+		return m.UpdateColumnsFunc({{$TableNameCamel}}, columns...)
+	}
+	return nil
+}
+
+// UpdateChanged ...
+func (m *{{ $TableNamePascal }}) UpdateChanged({{$TableNameCamel}} *model.{{$TableNamePascal}}) error {
+	m.record("UpdateChanged", {{$TableNameCamel}})
+	if m.UpdateChangedFunc != nil {
+		return m.UpdateChangedFunc({{$TableNameCamel}})
+	}
+	return nil
+}
+
+// DeleteBy{{$PKName}} ...
+func (m *{{ $TableNamePascal }}) DeleteBy{{$PKName}}({{$PKParams}}) error {
+	m.record("DeleteBy{{$PKName}}", {{$PKArgs}})
+	if m.DeleteBy{{$PKName}}Func != nil {
+		return m.DeleteBy{{$PKName}}Func({{$PKArgs}})
+	}
+	return nil
+}
+{{if $SoftDelete}}
+// RestoreBy{{$PKName}} ...
+func (m *{{ $TableNamePascal }}) RestoreBy{{$PKName}}({{$PKParams}}) error {
+	m.record("RestoreBy{{$PKName}}", {{$PKArgs}})
+	if m.RestoreBy{{$PKName}}Func != nil {
+		return m.RestoreBy{{$PKName}}Func({{$PKArgs}})
+	}
+	return nil
+}
+
+// HardDeleteBy{{$PKName}} ...
+func (m *{{ $TableNamePascal }}) HardDeleteBy{{$PKName}}({{$PKParams}}) error {
+	m.record("HardDeleteBy{{$PKName}}", {{$PKArgs}})
+	if m.HardDeleteBy{{$PKName}}Func != nil {
+		return m.HardDeleteBy{{$PKName}}Func({{$PKArgs}})
+	}
+	return nil
+}
+{{end}}{{end}}
+// WithExecutor returns the mock itself, so that the calls in a transaction are recorded as well
+func (m *{{ $TableNamePascal }}) WithExecutor(exec dao.Executor) dao.{{ $TableNamePascal }} {
+	m.record("WithExecutor", exec)
+	return m
+}
+
+//----------------------------------------
+// Compiler Check
+//----------------------------------------
+
+var _ dao.{{ $TableNamePascal }}Generated = &{{ $TableNamePascal }}{}
+var _ dao.{{ $TableNamePascal }} = &{{ $TableNamePascal }}{}
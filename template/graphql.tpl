@@ -0,0 +1,19 @@
+# Automatically generated by gendao.
+# Source: {{ .Config.MysqlConfig.DbName }}
+
+# ********************
+# *** DO NOT EDIT! ***
+# ********************
+{{range .GraphQLScalars}}
+scalar {{.}}{{end}}
+{{with .GraphQLQueries}}
+type Query {
+{{range .}}  {{.Name}}({{range $i, $a := .Args}}{{if ne $i 0}}, {{end}}{{$a.Name}}: {{$a.Type}}{{end}}): {{.Type}}
+{{end}}}
+{{end}}{{range .Tables}}
+type {{.NameByPascalcase}} {
+{{range .Columns}}{{with .GraphQLDescription}}  {{.}}
+{{end}}  {{.NameByCamelcase}}: {{.GraphQLType}}
+{{end}}{{range .GraphQLRelations $.Tables}}  {{.Name}}: {{.Type}}
+{{end}}}
+{{end}}
@@ -0,0 +1 @@
+{{ .Table.JSONSchema | toJSON }}
\ No newline at end of file
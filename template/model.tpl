@@ -1,21 +1,37 @@
 package model
-
+{{$useNull := false}}{{range .CommonColumns}}{{if contains .Type "null."}}{{$useNull = true}}{{end}}{{end}}
 import (
 	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"gopkg.in/gorp.v1"
+	"gopkg.in/gorp.v1"{{if $useNull}}
+	"gopkg.in/guregu/null.v3"{{end}}
 )
 
 // Model ...
 type Model struct { {{range .CommonColumns}}
-  {{ print .NameByPascalcase " " .Type "`db:\"" .Name "\"`" }}{{end}}
+  {{ .NameByPascalcase }} {{ .Type }} `db:"{{ .Name }}"{{if $.Config.JSONNaming}} json:"{{ .JSONName }}"{{end}}`{{end}}
 }
 
-const baseString = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+// DummySeedEnv is the environment variable to set the seed of the dummy models
+const DummySeedEnv = "GENDAO_DUMMY_SEED"
+
+var (
+	dummySeed   int64
+	dummySource = &lockedSource{}
+	dummyRand   = rand.New(dummySource)
+)
 
 func init() {
-	rand.Seed(time.Now().UnixNano())
+	seed := time.Now().UnixNano()
+	if v, err := strconv.ParseInt(os.Getenv(DummySeedEnv), 10, 64); err == nil {
+		seed = v
+	}
+	SetDummySeed(seed)
 }
 
 // PreInsert is previous insert func
@@ -44,45 +60,64 @@ func (m *Model) preUpdate(_ gorp.SqlExecutor, now time.Time) error {
 // NewDummyModel is generate new dummy model
 func NewDummyModel() Model {
 	return Model{
-		UpdatedAt: time.Unix(int64(rand.Uint32()), 0),
-		CreatedAt: time.Unix(int64(rand.Uint32()), 0),
+		UpdatedAt: time.Unix(int64(dummyRand.Uint32()), 0),
+		CreatedAt: time.Unix(int64(dummyRand.Uint32()), 0),{{range .CommonColumns}}{{if .Version}}
+		{{.NameByPascalcase}}: 1,{{end}}{{end}}
 	}
 }
 
-func randIntn(max int) int {
-	return rand.Intn(max)
+// SetDummySeed sets the seed of the dummy models.
+// The same seed generates the same dummy models as long as they are generated in the same order.
+func SetDummySeed(seed int64) {
+	atomic.StoreInt64(&dummySeed, seed)
+	dummySource.Seed(seed)
 }
 
-func randString(length int) string {
-	if length <= 0 {
-		return ""
-	}
-	b := make([]byte, int(length))
-	for i := range b {
-		b[i] = baseString[int(rand.Int63()%int64(len(baseString)))]
-	}
-	return string(b)
+// DummySeed returns the seed of the dummy models
+func DummySeed() int64 {
+	return atomic.LoadInt64(&dummySeed)
 }
 
-func randStringRange(min, max int) string {
-	if min >= max {
-		return ""
-	}
-	length := int(rand.Int63() % int64(max - min))
-	if length > 10000 {
-		length = 10000 // limiter
-	}
-	return randString(length)
+// NewDummyRand returns new rand derived from the dummy seed, for NewDummy{Table}WithRand.
+// It is not safe for concurrent use, so use one per goroutine.
+func NewDummyRand() *rand.Rand {
+	return rand.New(rand.NewSource(dummyRand.Int63()))
+}
+
+// TB is the part of testing.TB used by ReportDummySeed and CreateDummy{Table} of dao
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Failed() bool
+	Logf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// ReportDummySeed logs the dummy seed when the test fails, so that the test can be reproduced with it
+func ReportDummySeed(t TB) {
+	t.Helper()
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("dummy seed: %d (run with %s=%d to reproduce)", DummySeed(), DummySeedEnv, DummySeed())
+		}
+	})
+}
+
+// lockedSource is rand.Source which is safe for concurrent use
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
 }
 
-func randTime() time.Time {
-	return time.Unix(rand.Int63n(int64(3000*365*24*60*60)), rand.Int63n(int64(time.Second)))
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
 }
 
-func randNullTime() null.Time {
-  valid := rand.Intn(2) == 0
-  if !valid {
-    return null.Time{}
-  }
-  return null.TimeFrom(randTime())
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src = rand.NewSource(seed)
 }
@@ -19,34 +19,65 @@ import (
 // {{ print $TableNamePascal " " $TableNameCamel }} model
 // +gen slice:"GroupBy[string],Select[string],SortBy,Where"
 type {{ $TableNamePascal }} struct { {{range .Table.Columns}}{{if contains $CommonColumns .Name}}{{else}}
-  {{ print .NameByPascalcase " " .Type "`db:\"" .Name "\"`" }}{{end}}{{end}}
+  {{ .NameByPascalcase }} {{ .Type }} `db:"{{ .Name }}"{{if $.Config.JSONNaming}} json:"{{ .JSONName }}"{{end}}`{{end}}{{end}}
   Model
+  changed []{{ $TableNamePascal }}Column `db:"-"`
 }
+
+// {{ $TableNamePascal }}Column is column name of {{ $TableNameCamel }}
+type {{ $TableNamePascal }}Column string
+
+// column names of {{ $TableNameCamel }}
+const ( {{range .Table.Columns}}
+	{{ $TableNamePascal }}Column{{.NameByPascalcase}} {{ $TableNamePascal }}Column = "{{.Name}}"{{end}}
+)
 {{ $counter := print $TableNameCamel "Counter" }}
 var {{ $counter }} uint64
 {{ $privateDummyMethod := print "newDummy" $TableNamePascal}}
+// {{ $TableNamePascal }}Option is option of NewDummy{{ $TableNamePascal }}, which overwrites the dummy value
+type {{ $TableNamePascal }}Option func(*{{ $TableNamePascal }})
+{{range .Table.Columns}}
+// With{{ $TableNamePascal }}{{.NameByPascalcase}} is option to set {{.Name}} of the dummy {{ $TableNameCamel }}
+func With{{ $TableNamePascal }}{{.NameByPascalcase}}(v {{.Type}}) {{ $TableNamePascal }}Option {
+	return func(m *{{ $TableNamePascal }}) {
+		m.{{.NameByPascalcase}} = v
+	}
+}
+{{end}}
 // NewDummy{{ $TableNamePascal }} is generate new dummy {{ $TableNameCamel }}
-func NewDummy{{ print $TableNamePascal "() " $TableNamePascal }} {
+func NewDummy{{ $TableNamePascal }}(opts ...{{ $TableNamePascal }}Option) {{ $TableNamePascal }} {
+	return NewDummy{{ $TableNamePascal }}WithRand(dummyRand, opts...)
+}
+
+// NewDummy{{ $TableNamePascal }}WithRand is generate new dummy {{ $TableNameCamel }} with r, which is not locked unlike NewDummy{{ $TableNamePascal }}
+func NewDummy{{ $TableNamePascal }}WithRand(r *rand.Rand, opts ...{{ $TableNamePascal }}Option) {{ $TableNamePascal }} {
 	counter := atomic.AddUint64(&{{ $counter }}, 1)
-	return {{ $privateDummyMethod }}(counter, true)
+	return {{ $privateDummyMethod }}(r, counter, true, opts)
 }
 {{ if .Table.PrimaryKey.AutoIncrement }}
 // NewDummy{{ $TableNamePascal }}WithoutPK is generate new dummy {{ $TableNameCamel }} without PK
-func NewDummy{{ $TableNamePascal }}WithoutPK() {{ $TableNamePascal }} {
+func NewDummy{{ $TableNamePascal }}WithoutPK(opts ...{{ $TableNamePascal }}Option) {{ $TableNamePascal }} {
+	return NewDummy{{ $TableNamePascal }}WithoutPKWithRand(dummyRand, opts...)
+}
+
+// NewDummy{{ $TableNamePascal }}WithoutPKWithRand is generate new dummy {{ $TableNameCamel }} without PK with r
+func NewDummy{{ $TableNamePascal }}WithoutPKWithRand(r *rand.Rand, opts ...{{ $TableNamePascal }}Option) {{ $TableNamePascal }} {
 	counter := atomic.AddUint64(&{{ $counter }}, 1)
-	return {{ $privateDummyMethod }}(counter, false)
+	return {{ $privateDummyMethod }}(r, counter, false, opts)
 }{{end}}
 
-func {{ $privateDummyMethod }}(counter uint64, fillsPK bool) {{ $TableNamePascal }} {
-	rand.Seed(time.Now().UnixNano())
+func {{ $privateDummyMethod }}(r *rand.Rand, counter uint64, fillsPK bool, opts []{{ $TableNamePascal }}Option) {{ $TableNamePascal }} {
 	m := {{ $TableNamePascal }}{ {{range .Table.Columns}}{{ if .Primary }}
 		{{ print .NameByPascalcase ": " }} {{if eq .Type "string"}}""{{else}}0{{end}},{{else}}{{if not .Common}}
 		{{ print .NameByPascalcase ": " .SampleValue ","}}{{end}}{{end}}{{end}}
 	}
 	if fillsPK { {{range .Table.PrimaryKey.Columns}}{{if eq .Type "string"}}
-		m.{{.NameByPascalcase}} = fmt.Sprintf("%s%d", randString(10), counter){{else}}
+		m.{{.NameByPascalcase}} = fmt.Sprintf("%s%d", dummy.String(r, 10, 10), counter){{else}}
 		m.{{.NameByPascalcase}} = {{.Type}}(counter){{end}}{{end}}
 	}
+	for _, opt := range opts {
+		opt(&m)
+	}
 	return m
 }
 
@@ -55,6 +86,50 @@ func (m {{ $TableNamePascal }}) TableName() string {
 	return "{{ .Table.Name }}"
 }
 
+{{if .Table.PrimaryKey.Columns}}// {{ $TableNamePascal }}PK is primary key of {{ $TableNameCamel }}
+type {{ $TableNamePascal }}PK struct { {{range .Table.PrimaryKey.Columns}}
+	{{ print .NameByPascalcase " " .Type }}{{end}}
+}
+
+// PK is get primary key
+func (m {{ $TableNamePascal }}) PK() {{ $TableNamePascal }}PK {
+	return {{ $TableNamePascal }}PK{ {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}{{.NameByPascalcase}}: m.{{.NameByPascalcase}}{{end}} }
+}
+
+{{end}}{{range .Table.Columns}}{{if not (or .Primary .Common .Version)}}// Set{{.NameByPascalcase}} is set {{.Name}} and record it as changed
+func (m *{{ $TableNamePascal }}) Set{{.NameByPascalcase}}(v {{.Type}}) {
+	m.{{.NameByPascalcase}} = v
+	m.setChanged({{ $TableNamePascal }}Column{{.NameByPascalcase}})
+}
+
+{{end}}{{end}}func (m *{{ $TableNamePascal }}) setChanged(column {{ $TableNamePascal }}Column) {
+	for _, c := range m.changed {
+		if c == column {
+			return
+		}
+	}
+	m.changed = append(m.changed, column)
+}
+
+// ChangedColumns is get columns changed by the setters
+func (m {{ $TableNamePascal }}) ChangedColumns() []{{ $TableNamePascal }}Column {
+	return m.changed
+}
+
+// ClearChanged is clear the changed columns
+func (m *{{ $TableNamePascal }}) ClearChanged() {
+	m.changed = nil
+}
+
+// ColumnValue is get value of the column
+func (m {{ $TableNamePascal }}) ColumnValue(column {{ $TableNamePascal }}Column) interface{} {
+	switch column { {{range .Table.Columns}}
+	case {{ $TableNamePascal }}Column{{.NameByPascalcase}}:
+		return m.{{.NameByPascalcase}}{{end}}
+	}
+	return nil
+}
+
 // PrimaryKeys is get primary keys for table
 func (m {{ $TableNamePascal }}) PrimaryKeys() []string {
 	return []string{ {{range $i, $c := .Table.PrimaryKey.Columns}}{{if ne $i 0}}, {{end}}"{{.Name}}"{{end}} }
@@ -0,0 +1 @@
+{{ .OpenAPIComponents | toJSON }}
\ No newline at end of file
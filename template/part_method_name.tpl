@@ -1 +1 @@
-{{.Name}}({{range $i, $p := .Params}}{{if ne $i 0}}, {{end}}{{$p.NameByCamelcase}} {{$p.Type}}{{end}}) ({{if .ReturnMany}}model.{{.ReturnModel}}Slice{{else}}*model.{{.ReturnModel}}{{end}}, error)
\ No newline at end of file
+{{define "part_method_signature"}}({{range $i, $p := .Params}}{{if ne $i 0}}, {{end}}{{$p.NameByCamelcase}} {{$p.Type}}{{end}}) ({{if .Count}}int64{{else if .Exists}}bool{{else if .Cursors}}model.{{.ReturnModel}}Slice, string{{else if .ReturnMany}}model.{{.ReturnModel}}Slice{{else}}*model.{{.ReturnModel}}{{end}}, error){{end}}{{.Name}}{{template "part_method_signature" .}}
\ No newline at end of file
@@ -0,0 +1,27 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+package pbconv
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/guregu/null.v3"
+)
+
+func nullTimeToProto(t null.Time) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+func nullTimeFromProto(ts *timestamppb.Timestamp) null.Time {
+	if ts == nil {
+		return null.Time{}
+	}
+	return null.TimeFrom(ts.AsTime())
+}
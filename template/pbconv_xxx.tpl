@@ -0,0 +1,52 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}/{{ .Table.Name }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+package pbconv
+{{$useNull := false}}{{$useTimestamp := false}}{{range .Table.Columns}}{{if .ProtoType}}{{if eq .Type "time.Time"}}{{$useTimestamp = true}}{{else if and (contains .Type "null.") (ne .Type "null.Time")}}{{$useNull = true}}{{end}}{{end}}{{end}}
+import ({{if $useTimestamp}}
+	"google.golang.org/protobuf/types/known/timestamppb"{{end}}{{if $useNull}}
+	"gopkg.in/guregu/null.v3"{{end}}
+
+	"{{ .Config.PackageRoot }}/model"
+	pb "{{ .Config.GetProtoGoPackage }}"
+)
+{{$TableNamePascal := .Table.NameByPascalcase}}
+{{$TableNameCamel := .Table.NameByCamelcase}}
+// {{ $TableNamePascal }}ToProto converts model.{{ $TableNamePascal }} to pb.{{ $TableNamePascal }}
+func {{ $TableNamePascal }}ToProto(m model.{{ $TableNamePascal }}) *pb.{{ $TableNamePascal }} {
+	return &pb.{{ $TableNamePascal }}{ {{range .Table.Columns}}{{if .ProtoType}}
+		{{.ProtoGoName}}: {{.ToProto (print "m." .NameByPascalcase)}},{{end}}{{end}}
+	}
+}
+
+// {{ $TableNamePascal }}FromProto converts pb.{{ $TableNamePascal }} to model.{{ $TableNamePascal }}, nil is converted to zero value
+func {{ $TableNamePascal }}FromProto(p *pb.{{ $TableNamePascal }}) model.{{ $TableNamePascal }} {
+	var m model.{{ $TableNamePascal }}
+	if p == nil {
+		return m
+	}{{range .Table.Columns}}{{if .ProtoType}}
+	m.{{.NameByPascalcase}} = {{.FromProto (print "p." .ProtoGoName)}}{{end}}{{end}}
+	return m
+}
+
+// {{ $TableNamePascal }}SliceToProto converts model.{{ $TableNamePascal }}Slice to pb.{{ $TableNamePascal }} slice
+func {{ $TableNamePascal }}SliceToProto({{ $TableNameCamel }}s model.{{ $TableNamePascal }}Slice) []*pb.{{ $TableNamePascal }} {
+	res := make([]*pb.{{ $TableNamePascal }}, len({{ $TableNameCamel }}s))
+	for i, m := range {{ $TableNameCamel }}s {
+		res[i] = {{ $TableNamePascal }}ToProto(m)
+	}
+	return res
+}
+
+// {{ $TableNamePascal }}SliceFromProto converts pb.{{ $TableNamePascal }} slice to model.{{ $TableNamePascal }}Slice
+func {{ $TableNamePascal }}SliceFromProto(ps []*pb.{{ $TableNamePascal }}) model.{{ $TableNamePascal }}Slice {
+	res := make(model.{{ $TableNamePascal }}Slice, len(ps))
+	for i, p := range ps {
+		res[i] = {{ $TableNamePascal }}FromProto(p)
+	}
+	return res
+}
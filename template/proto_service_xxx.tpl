@@ -0,0 +1,40 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}/{{ .Table.Name }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+syntax = "proto3";
+
+package {{ .Config.GetProtoPackage }};
+
+option go_package = "{{ .Config.GetProtoGoPackage }}";
+{{$TableNamePascal := .Table.NameByPascalcase}}{{$PK := .Table.PrimaryKey}}{{if $PK.Columns}}
+import "google/protobuf/empty.proto";
+import "{{ .Table.FileName }}.proto";
+
+// {{ $TableNamePascal }}Service is CRUD of {{ .Table.Name }} by the primary key
+service {{ $TableNamePascal }}Service {
+  rpc Get{{ $TableNamePascal }}(Get{{ $TableNamePascal }}Request) returns ({{ $TableNamePascal }});
+  rpc Create{{ $TableNamePascal }}(Create{{ $TableNamePascal }}Request) returns ({{ $TableNamePascal }});
+  rpc Update{{ $TableNamePascal }}(Update{{ $TableNamePascal }}Request) returns ({{ $TableNamePascal }});
+  rpc Delete{{ $TableNamePascal }}(Delete{{ $TableNamePascal }}Request) returns (google.protobuf.Empty);
+}
+
+message Get{{ $TableNamePascal }}Request {{"{"}}{{range $i, $c := $PK.Columns}}
+  {{$c.ProtoType}} {{$c.Name}} = {{inc $i}};{{end}}
+}
+
+message Create{{ $TableNamePascal }}Request {
+  {{ $TableNamePascal }} {{ .Table.FileName }} = 1;
+}
+
+message Update{{ $TableNamePascal }}Request {
+  {{ $TableNamePascal }} {{ .Table.FileName }} = 1;
+}
+
+message Delete{{ $TableNamePascal }}Request {{"{"}}{{range $i, $c := $PK.Columns}}
+  {{$c.ProtoType}} {{$c.Name}} = {{inc $i}};{{end}}
+}
+{{end}}
@@ -0,0 +1,21 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}/{{ .Table.Name }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+syntax = "proto3";
+
+package {{ .Config.GetProtoPackage }};
+
+option go_package = "{{ .Config.GetProtoGoPackage }}";
+{{if .Table.ProtoUseTimestamp}}
+import "google/protobuf/timestamp.proto";
+{{end}}
+// {{ .Table.NameByPascalcase }} is a row of {{ .Table.Name }}.
+// The field numbers are the ordinal positions of the columns.
+message {{ .Table.NameByPascalcase }} {{"{"}}{{range .Table.Columns}}{{if .ProtoType}}{{with .Column.ColumnComment}}
+  // {{.}}{{end}}
+  {{.ProtoType}} {{.Name}} = {{.Column.OrdinalPosition}};{{end}}{{end}}
+}
@@ -0,0 +1,12 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}/{{ .Table.Name }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+/** {{ .Table.NameByPascalcase }} is a row of {{ .Table.Name }} */
+export interface {{ .Table.NameByPascalcase }} {
+{{range .Table.Columns}}{{with .TSDoc}}  {{.}}
+{{end}}  {{.TSName}}: {{.TSType}};
+{{end}}}
@@ -0,0 +1,16 @@
+// Automatically generated by gendao.
+// Source: {{ .Config.MysqlConfig.DbName }}/{{ .Table.Name }}
+
+// ********************
+// *** DO NOT EDIT! ***
+// ********************
+
+import { z } from "zod";
+
+/** {{ .Table.NameByCamelcase }}Schema validates a row of {{ .Table.Name }} */
+export const {{ .Table.NameByCamelcase }}Schema = z.object({
+{{range .Table.Columns}}{{with .TSDoc}}  {{.}}
+{{end}}  {{.TSName}}: {{.ZodType}},
+{{end}}});
+
+export type {{ .Table.NameByPascalcase }}Schema = z.infer<typeof {{ .Table.NameByCamelcase }}Schema>;